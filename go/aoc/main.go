@@ -0,0 +1,74 @@
+package main
+
+import (
+	"adventOfCode/common/gen"
+	"errors"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+var osExit = os.Exit
+
+func main() {
+	log.SetFlags(0)
+
+	args := os.Args[1:]
+	if len(args) == 0 {
+		log.Printf("Error: %s\n", errors.New("no command provided"))
+		osExit(1)
+		return
+	}
+
+	switch args[0] {
+	case "gen":
+		runGen(args[1:])
+	default:
+		log.Printf("Error: unknown command [%s]\n", args[0])
+		osExit(1)
+	}
+}
+
+func runGen(args []string) {
+	flags := flag.NewFlagSet("gen", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	day := flags.Int("day", 0, "day to generate input for")
+	seed := flags.Int64("seed", 1, "random seed")
+	size := flags.Int("size", 100, "lines, games, rows or cards to generate")
+	wordRate := flags.Float64("word-rate", 0.5, "day 1: share of values spelled out as words")
+	colors := flags.String("colors", "red,green,blue", "day 2: comma separated cube colors")
+	rounds := flags.Int("rounds", 6, "day 2: maximum rounds per game")
+	maxCubes := flags.Int("max-cubes", 20, "day 2: maximum cubes drawn per color")
+	numberDensity := flags.Float64("number-density", 0.1, "day 3: chance of a number starting in a cell")
+	symbolDensity := flags.Float64("symbol-density", 0.05, "day 3: chance of a symbol in a cell")
+	winning := flags.Int("winning", 10, "day 4: winning numbers per card")
+	drawn := flags.Int("drawn", 25, "day 4: drawn numbers per card")
+	winRate := flags.Float64("win-rate", 0.04, "day 4: chance of a drawn number winning")
+
+	if err := flags.Parse(args); err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	err := gen.Generate(
+		os.Stdout,
+		*day,
+		*seed,
+		*size,
+		gen.WithWordRate(*wordRate),
+		gen.WithColors(strings.Split(*colors, ",")...),
+		gen.WithRounds(*rounds),
+		gen.WithMaxCubes(*maxCubes),
+		gen.WithDensity(*numberDensity, *symbolDensity),
+		gen.WithPoolSizes(*winning, *drawn),
+		gen.WithWinRate(*winRate),
+	)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+}
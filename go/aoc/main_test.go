@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestApplicationShould(t *testing.T) {
+
+	t.Run("generate input for a day", func(t *testing.T) {
+		os.Args = []string{"cmd", "gen", "--day", "2", "--seed", "5", "--size", "3", "--colors", "red,blue"}
+
+		actualOut, actualCode, _ := captureStdOut(main)
+
+		expectedCode := 0
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+		assert.Equal(t, 3, strings.Count(actualOut, "\n"), "Did not generate the requested size")
+		assert.True(t, strings.HasPrefix(actualOut, "Game 1: "), "Did not generate games")
+		assert.NotContains(t, actualOut, "green", "Did not use the configured colors")
+	})
+
+	t.Run("generate the same input for the same seed", func(t *testing.T) {
+		os.Args = []string{"cmd", "gen", "--day", "3", "--seed", "9", "--size", "10"}
+		first, _, _ := captureStdOut(main)
+
+		os.Args = []string{"cmd", "gen", "--day", "3", "--seed", "9", "--size", "10"}
+		second, _, _ := captureStdOut(main)
+
+		assert.Equal(t, first, second, "Did not generate the same input")
+	})
+
+	t.Run("fail for unknown days", func(t *testing.T) {
+		os.Args = []string{"cmd", "gen", "--day", "12"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 1
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+	})
+
+	t.Run("fail for duplicate colors", func(t *testing.T) {
+		os.Args = []string{"cmd", "gen", "--day", "2", "--colors", "red,red,blue"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 1
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+	})
+
+	t.Run("fail for unknown commands", func(t *testing.T) {
+		os.Args = []string{"cmd", "solve"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 1
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+	})
+
+	t.Run("fail when no command is passed", func(t *testing.T) {
+		os.Args = []string{"cmd"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 1
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+	})
+
+	t.Run("fail for unknown flags", func(t *testing.T) {
+		os.Args = []string{"cmd", "gen", "--colour", "red"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 1
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+	})
+
+}
+
+func captureErrorCode(f func()) int {
+	originalExit := osExit
+	exitCode := 0
+
+	defer func() {
+		osExit = originalExit
+	}()
+
+	osExit = func(code int) {
+		exitCode = code
+	}
+
+	f()
+
+	return exitCode
+}
+
+func captureStdOut(f func()) (string, int, error) {
+	originalStdout := os.Stdout
+	originalExit := osExit
+	exitCode := 0
+
+	defer func() {
+		os.Stdout = originalStdout
+		osExit = originalExit
+	}()
+
+	osExit = func(code int) {
+		exitCode = code
+	}
+
+	inPipe, outPipe, _ := os.Pipe()
+	os.Stdout = outPipe
+
+	f()
+
+	if outPipe.Close() != nil {
+		return "", -1, errors.New("unable to close output pipe")
+	}
+
+	var buffer bytes.Buffer
+	_, err := io.Copy(&buffer, inPipe)
+	if err != nil {
+		return "", -1, errors.New("unable to capture input pipe")
+	}
+
+	return buffer.String(), exitCode, nil
+}
+
+func BenchmarkMain(b *testing.B) {
+
+	b.Run("generate input", func(b *testing.B) {
+		os.Args = []string{"cmd", "gen", "--day", "1", "--size", "10"}
+
+		for i := 0; i < b.N; i++ {
+			_, _, _ = captureStdOut(main)
+		}
+	})
+
+}
@@ -0,0 +1,275 @@
+package gen
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var colorPattern = regexp.MustCompile(`^\pL+$`)
+
+type Option func(*settings)
+
+type settings struct {
+	wordRate      float64
+	colors        []string
+	maxRounds     int
+	maxCubes      int
+	numberDensity float64
+	symbolDensity float64
+	winningPool   int
+	drawnPool     int
+	winRate       float64
+}
+
+type generator func(w *bufio.Writer, random *rand.Rand, size int, config settings)
+
+var generators = map[int]generator{
+	1: generateCalibrations,
+	2: generateGames,
+	3: generateSchematic,
+	4: generateScratchcards,
+}
+
+var digitWords = []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+
+var overlappingWords = []string{"twone", "oneight", "threeight", "fiveight", "sevenine", "eightwo", "eighthree", "nineight"}
+
+const fillerLetters = "abcdfghjklmpqrsuvwxyz"
+
+const schematicSymbols = "#$%&*+-/=@"
+
+const maxCardNumber = 99
+
+func WithWordRate(rate float64) Option {
+	return func(s *settings) {
+		s.wordRate = rate
+	}
+}
+
+func WithColors(colors ...string) Option {
+	return func(s *settings) {
+		s.colors = colors
+	}
+}
+
+func WithRounds(maxRounds int) Option {
+	return func(s *settings) {
+		s.maxRounds = maxRounds
+	}
+}
+
+func WithMaxCubes(maxCubes int) Option {
+	return func(s *settings) {
+		s.maxCubes = maxCubes
+	}
+}
+
+func WithDensity(numbers float64, symbols float64) Option {
+	return func(s *settings) {
+		s.numberDensity = numbers
+		s.symbolDensity = symbols
+	}
+}
+
+func WithPoolSizes(winning int, drawn int) Option {
+	return func(s *settings) {
+		s.winningPool = winning
+		s.drawnPool = drawn
+	}
+}
+
+func WithWinRate(rate float64) Option {
+	return func(s *settings) {
+		s.winRate = rate
+	}
+}
+
+func Days() []int {
+	var days []int
+	for day := range generators {
+		days = append(days, day)
+	}
+	slices.Sort(days)
+
+	return days
+}
+
+func Generate(w io.Writer, day int, seed int64, size int, opts ...Option) error {
+	config := settings{
+		wordRate:      0.5,
+		colors:        []string{"red", "green", "blue"},
+		maxRounds:     6,
+		maxCubes:      20,
+		numberDensity: 0.1,
+		symbolDensity: 0.05,
+		winningPool:   10,
+		drawnPool:     25,
+		winRate:       0.04,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	generate, ok := generators[day]
+	if !ok {
+		return fmt.Errorf("no generator for day [%d]", day)
+	}
+
+	if err := config.validate(size); err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(w)
+	generate(writer, rand.New(rand.NewSource(seed)), size, config)
+
+	return writer.Flush()
+}
+
+func (s settings) validate(size int) error {
+	switch {
+	case size < 1:
+		return fmt.Errorf("size must be positive, got [%d]", size)
+	case len(s.colors) == 0:
+		return fmt.Errorf("at least one color is required")
+	case s.maxRounds < 1 || s.maxCubes < 1:
+		return fmt.Errorf("rounds and cubes must be positive, got [%d] and [%d]", s.maxRounds, s.maxCubes)
+	case s.numberDensity < 0 || s.symbolDensity < 0 || s.numberDensity+s.symbolDensity > 1:
+		return fmt.Errorf("densities must be non-negative and sum to at most 1, got [%g] and [%g]", s.numberDensity, s.symbolDensity)
+	case s.winningPool < 1 || s.drawnPool < 1 || s.winningPool+s.drawnPool > maxCardNumber:
+		return fmt.Errorf("pool sizes must be positive and sum to at most %d, got [%d] and [%d]", maxCardNumber, s.winningPool, s.drawnPool)
+	case s.wordRate < 0 || s.wordRate > 1 || s.winRate < 0 || s.winRate > 1:
+		return fmt.Errorf("rates must be between 0 and 1, got [%g] and [%g]", s.wordRate, s.winRate)
+	}
+
+	return validateColors(s.colors)
+}
+
+func validateColors(colors []string) error {
+	for i, color := range colors {
+		if !colorPattern.MatchString(color) {
+			return fmt.Errorf("colors must be non-empty words, got [%s]", color)
+		}
+
+		if slices.Contains(colors[:i], color) {
+			return fmt.Errorf("duplicate color [%s]", color)
+		}
+	}
+
+	return nil
+}
+
+func generateCalibrations(w *bufio.Writer, random *rand.Rand, size int, config settings) {
+	for range size {
+		tokens := 1 + random.Intn(6)
+		valueAt := random.Intn(tokens)
+
+		for i := range tokens {
+			if i != valueAt && random.Intn(2) == 0 {
+				for range 1 + random.Intn(4) {
+					_ = w.WriteByte(fillerLetters[random.Intn(len(fillerLetters))])
+				}
+				continue
+			}
+
+			switch {
+			case random.Float64() >= config.wordRate:
+				_ = w.WriteByte(byte('1' + random.Intn(9)))
+			case random.Intn(4) == 0:
+				_, _ = w.WriteString(overlappingWords[random.Intn(len(overlappingWords))])
+			default:
+				_, _ = w.WriteString(digitWords[random.Intn(len(digitWords))])
+			}
+		}
+
+		_ = w.WriteByte('\n')
+	}
+}
+
+func generateGames(w *bufio.Writer, random *rand.Rand, size int, config settings) {
+	for id := 1; id <= size; id++ {
+		rounds := make([]string, 1+random.Intn(config.maxRounds))
+
+		for i := range rounds {
+			colors := slices.Clone(config.colors)
+			random.Shuffle(len(colors), func(a int, b int) {
+				colors[a], colors[b] = colors[b], colors[a]
+			})
+			colors = colors[:1+random.Intn(len(colors))]
+
+			draws := make([]string, len(colors))
+			for j, color := range colors {
+				draws[j] = fmt.Sprintf("%d %s", 1+random.Intn(config.maxCubes), color)
+			}
+
+			rounds[i] = strings.Join(draws, ", ")
+		}
+
+		_, _ = fmt.Fprintf(w, "Game %d: %s\n", id, strings.Join(rounds, "; "))
+	}
+}
+
+func generateSchematic(w *bufio.Writer, random *rand.Rand, size int, config settings) {
+	row := make([]byte, size)
+
+	for range size {
+		for col := 0; col < size; col++ {
+			roll := random.Float64()
+			isAfterNumber := col > 0 && row[col-1] >= '0' && row[col-1] <= '9'
+
+			switch {
+			case roll < config.numberDensity && !isAfterNumber:
+				length := min(1+random.Intn(3), size-col)
+				row[col] = byte('1' + random.Intn(9))
+				for offset := 1; offset < length; offset++ {
+					row[col+offset] = byte('0' + random.Intn(10))
+				}
+				col += length - 1
+			case roll < config.numberDensity+config.symbolDensity:
+				row[col] = schematicSymbols[random.Intn(len(schematicSymbols))]
+			default:
+				row[col] = '.'
+			}
+		}
+
+		_, _ = w.Write(row)
+		_ = w.WriteByte('\n')
+	}
+}
+
+func generateScratchcards(w *bufio.Writer, random *rand.Rand, size int, config settings) {
+	for id := 1; id <= size; id++ {
+		numbers := random.Perm(maxCardNumber)
+		winning := numbers[:config.winningPool]
+		losing := numbers[config.winningPool:]
+
+		drawn := make([]int, config.drawnPool)
+		matches := 0
+		for i := range drawn {
+			if matches < len(winning) && random.Float64() < config.winRate {
+				drawn[i] = winning[matches]
+				matches++
+			} else {
+				drawn[i] = losing[i-matches]
+			}
+		}
+		random.Shuffle(len(drawn), func(a int, b int) {
+			drawn[a], drawn[b] = drawn[b], drawn[a]
+		})
+
+		_, _ = fmt.Fprintf(w, "Card %d: %s | %s\n", id, formatCardNumbers(winning), formatCardNumbers(drawn))
+	}
+}
+
+func formatCardNumbers(numbers []int) string {
+	formatted := make([]string, len(numbers))
+	for i, number := range numbers {
+		formatted[i] = fmt.Sprintf("%2d", number+1)
+	}
+
+	return strings.Join(formatted, " ")
+}
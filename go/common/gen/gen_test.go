@@ -0,0 +1,149 @@
+package gen
+
+import (
+	"adventOfCode/day2/gameids"
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func generate(t *testing.T, day int, seed int64, size int, opts ...Option) []string {
+	var out bytes.Buffer
+
+	err := Generate(&out, day, seed, size, opts...)
+	assert.Nil(t, err, "Did not generate input")
+
+	return strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+}
+
+func TestGenerationShould(t *testing.T) {
+
+	for _, day := range Days() {
+		t.Run(fmt.Sprintf("generate deterministic input for day %d", day), func(t *testing.T) {
+			first := generate(t, day, 42, 20)
+			second := generate(t, day, 42, 20)
+			other := generate(t, day, 43, 20)
+
+			assert.Equal(t, first, second, "Did not generate the same input for the same seed")
+			assert.NotEqual(t, first, other, "Did not generate different input for different seeds")
+			assert.Len(t, first, 20, "Did not generate the requested size")
+		})
+	}
+
+	t.Run("generate calibration lines with digits or words", func(t *testing.T) {
+		valid := regexp.MustCompile(`\d|one|two|three|four|five|six|seven|eight|nine`)
+
+		for _, line := range generate(t, 1, 7, 200) {
+			assert.Regexp(t, valid, line, "Did not generate calibration value")
+		}
+	})
+
+	t.Run("generate only digits without words", func(t *testing.T) {
+		for _, line := range generate(t, 1, 7, 200, WithWordRate(0)) {
+			assert.Regexp(t, `^[a-z]*\d[a-z\d]*$`, line, "Did not generate only digits")
+			assert.NotRegexp(t, `one|two|three|four|five|six|seven|eight|nine`, line, "Did not avoid words")
+		}
+	})
+
+	t.Run("generate games with configured colors and rounds", func(t *testing.T) {
+		lines := generate(t, 2, 7, 50, WithColors("cyan", "magenta"), WithRounds(3), WithMaxCubes(4))
+
+		games, err := gameids.ParseGames(strings.NewReader(strings.Join(lines, "\n")))
+
+		assert.Nil(t, err, "Did not generate parsable games")
+		for i, game := range games {
+			assert.Equal(t, i+1, game.ID, "Did not number games")
+			assert.LessOrEqual(t, len(game.Rounds), 3, "Did not limit rounds")
+
+			for _, round := range game.Rounds {
+				for color, count := range round {
+					assert.Contains(t, []string{"cyan", "magenta"}, color, "Did not use configured colors")
+					assert.LessOrEqual(t, count, 4, "Did not limit cubes")
+				}
+			}
+		}
+	})
+
+	t.Run("generate square schematics with configured density", func(t *testing.T) {
+		sparse := strings.Join(generate(t, 3, 7, 60, WithDensity(0.01, 0.01)), "")
+		dense := strings.Join(generate(t, 3, 7, 60, WithDensity(0.3, 0.3)), "")
+
+		for _, line := range generate(t, 3, 7, 60) {
+			assert.Len(t, line, 60, "Did not generate square schematic")
+			assert.Regexp(t, `^[\d.#$%&*+\-/=@]+$`, line, "Did not generate schematic cells")
+		}
+		assert.Greater(t, strings.Count(sparse, "."), strings.Count(dense, "."), "Did not respect density")
+	})
+
+	t.Run("generate cards with configured pools and win rate", func(t *testing.T) {
+		card := regexp.MustCompile(`^Card (\d+): ([\d ]+) \| ([\d ]+)$`)
+
+		countMatches := func(lines []string) int {
+			matches := 0
+			for i, line := range lines {
+				match := card.FindStringSubmatch(line)
+				assert.NotNil(t, match, "Did not generate card")
+
+				id, _ := strconv.Atoi(match[1])
+				winning := strings.Fields(match[2])
+				drawn := strings.Fields(match[3])
+
+				assert.Equal(t, i+1, id, "Did not number cards")
+				assert.Len(t, winning, 5, "Did not respect winning pool size")
+				assert.Len(t, drawn, 8, "Did not respect drawn pool size")
+
+				for _, number := range drawn {
+					if slices.Contains(winning, number) {
+						matches++
+					}
+				}
+			}
+
+			return matches
+		}
+
+		never := countMatches(generate(t, 4, 7, 50, WithPoolSizes(5, 8), WithWinRate(0)))
+		often := countMatches(generate(t, 4, 7, 50, WithPoolSizes(5, 8), WithWinRate(0.8)))
+
+		assert.Equal(t, 0, never, "Did not respect zero win rate")
+		assert.Greater(t, often, 50, "Did not respect high win rate")
+	})
+
+	t.Run("fail for unknown days", func(t *testing.T) {
+		err := Generate(&bytes.Buffer{}, 9, 1, 10)
+
+		assert.EqualError(t, err, "no generator for day [9]", "Did not fail for unknown day")
+	})
+
+	failures := []struct {
+		name     string
+		day      int
+		size     int
+		opts     []Option
+		expected string
+	}{
+		{"invalid size", 1, 0, nil, "size must be positive, got [0]"},
+		{"missing colors", 2, 1, []Option{WithColors()}, "at least one color is required"},
+		{"duplicate colors", 2, 1, []Option{WithColors("red", "red", "blue")}, "duplicate color [red]"},
+		{"empty colors", 2, 1, []Option{WithColors("red", "")}, "colors must be non-empty words, got []"},
+		{"colors with whitespace", 2, 1, []Option{WithColors("light red")}, "colors must be non-empty words, got [light red]"},
+		{"colors with punctuation", 2, 1, []Option{WithColors("red;")}, "colors must be non-empty words, got [red;]"},
+		{"colors with digits", 2, 1, []Option{WithColors("red2")}, "colors must be non-empty words, got [red2]"},
+		{"invalid densities", 3, 1, []Option{WithDensity(0.8, 0.4)}, "densities must be non-negative and sum to at most 1, got [0.8] and [0.4]"},
+		{"invalid pools", 4, 1, []Option{WithPoolSizes(50, 50)}, "pool sizes must be positive and sum to at most 99, got [50] and [50]"},
+	}
+
+	for _, test := range failures {
+		t.Run("fail for "+test.name, func(t *testing.T) {
+			err := Generate(&bytes.Buffer{}, test.day, 1, test.size, test.opts...)
+
+			assert.EqualError(t, err, test.expected, "Did not fail for "+test.name)
+		})
+	}
+
+}
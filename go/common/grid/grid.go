@@ -0,0 +1,214 @@
+package grid
+
+import (
+	"bufio"
+	"io"
+	"iter"
+)
+
+type Point struct {
+	Row int
+	Col int
+}
+
+func (p Point) Add(other Point) Point {
+	return Point{p.Row + other.Row, p.Col + other.Col}
+}
+
+var Orthogonal = []Point{{-1, 0}, {0, 1}, {1, 0}, {0, -1}}
+
+var Diagonal = []Point{{-1, -1}, {-1, 1}, {1, 1}, {1, -1}}
+
+var Surrounding = []Point{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+
+type Grid[T any] struct {
+	rows [][]T
+}
+
+func New[T any](height int, width int) *Grid[T] {
+	rows := make([][]T, height)
+	for i := range rows {
+		rows[i] = make([]T, width)
+	}
+
+	return &Grid[T]{rows}
+}
+
+func FromRows[T any](rows [][]T) *Grid[T] {
+	return &Grid[T]{rows}
+}
+
+func Parse(reader io.Reader) (*Grid[byte], error) {
+	return ParseFunc(reader, func(cell byte) byte {
+		return cell
+	})
+}
+
+func ParseFunc[T any](reader io.Reader, convert func(byte) T) (*Grid[T], error) {
+	var rows [][]T
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		row := make([]T, len(line))
+		for i, cell := range line {
+			row[i] = convert(cell)
+		}
+
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return &Grid[T]{rows}, nil
+}
+
+func (g *Grid[T]) Height() int {
+	return len(g.rows)
+}
+
+func (g *Grid[T]) Width() int {
+	width := 0
+	for _, row := range g.rows {
+		width = max(width, len(row))
+	}
+
+	return width
+}
+
+func (g *Grid[T]) IsRectangular() bool {
+	for _, row := range g.rows {
+		if len(row) != len(g.rows[0]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (g *Grid[T]) InBounds(p Point) bool {
+	return p.Row >= 0 && p.Row < len(g.rows) && p.Col >= 0 && p.Col < len(g.rows[p.Row])
+}
+
+func (g *Grid[T]) At(p Point) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+
+	return g.rows[p.Row][p.Col], true
+}
+
+func (g *Grid[T]) Set(p Point, value T) bool {
+	if !g.InBounds(p) {
+		return false
+	}
+
+	g.rows[p.Row][p.Col] = value
+
+	return true
+}
+
+func (g *Grid[T]) Rows() [][]T {
+	return g.rows
+}
+
+func (g *Grid[T]) Row(row int) []T {
+	if row < 0 || row >= len(g.rows) {
+		return nil
+	}
+
+	return g.rows[row]
+}
+
+func (g *Grid[T]) Column(col int) []T {
+	var column []T
+
+	for _, row := range g.rows {
+		if col >= 0 && col < len(row) {
+			column = append(column, row[col])
+		}
+	}
+
+	return column
+}
+
+func (g *Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for rowIdx, row := range g.rows {
+			for colIdx, cell := range row {
+				if !yield(Point{rowIdx, colIdx}, cell) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (g *Grid[T]) Neighbours(p Point, directions []Point) iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for _, direction := range directions {
+			neighbour := p.Add(direction)
+
+			if cell, ok := g.At(neighbour); ok && !yield(neighbour, cell) {
+				return
+			}
+		}
+	}
+}
+
+func (g *Grid[T]) Neighbours4(p Point) iter.Seq2[Point, T] {
+	return g.Neighbours(p, Orthogonal)
+}
+
+func (g *Grid[T]) Neighbours8(p Point) iter.Seq2[Point, T] {
+	return g.Neighbours(p, Surrounding)
+}
+
+func (g *Grid[T]) Box(from Point, to Point) iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for row := max(from.Row, 0); row <= min(to.Row, len(g.rows)-1); row++ {
+			for col := max(from.Col, 0); col <= min(to.Col, len(g.rows[row])-1); col++ {
+				if !yield(Point{row, col}, g.rows[row][col]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Transpose pads ragged rows with the zero value, so the transposed grid and
+// the rotations built on it are rectangular and keep every cell in its place.
+func (g *Grid[T]) Transpose() *Grid[T] {
+	transposed := New[T](g.Width(), g.Height())
+	for rowIdx, row := range g.rows {
+		for colIdx, cell := range row {
+			transposed.rows[colIdx][rowIdx] = cell
+		}
+	}
+
+	return transposed
+}
+
+func (g *Grid[T]) RotateClockwise() *Grid[T] {
+	rotated := g.Transpose()
+	for _, row := range rotated.rows {
+		for i, j := 0, len(row)-1; i < j; i, j = i+1, j-1 {
+			row[i], row[j] = row[j], row[i]
+		}
+	}
+
+	return rotated
+}
+
+func (g *Grid[T]) RotateCounterClockwise() *Grid[T] {
+	rotated := g.Transpose()
+	for i, j := 0, len(rotated.rows)-1; i < j; i, j = i+1, j-1 {
+		rotated.rows[i], rotated.rows[j] = rotated.rows[j], rotated.rows[i]
+	}
+
+	return rotated
+}
@@ -0,0 +1,221 @@
+package grid
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func collect[T any](seq func(func(Point, T) bool)) ([]Point, []T) {
+	var points []Point
+	var cells []T
+
+	for point, cell := range seq {
+		points = append(points, point)
+		cells = append(cells, cell)
+	}
+
+	return points, cells
+}
+
+func TestGridShould(t *testing.T) {
+
+	t.Run("parse grid from reader", func(t *testing.T) {
+		actual, err := Parse(strings.NewReader("ABC\nDEF\n"))
+		expected := [][]byte{{'A', 'B', 'C'}, {'D', 'E', 'F'}}
+
+		assert.Nil(t, err, "Did not parse grid")
+		assert.Equal(t, expected, actual.Rows(), "Did not parse grid correctly")
+	})
+
+	t.Run("parse grid with conversion", func(t *testing.T) {
+		actual, _ := ParseFunc(strings.NewReader("12\n34"), func(cell byte) int {
+			return int(cell - '0')
+		})
+		expected := [][]int{{1, 2}, {3, 4}}
+
+		assert.Equal(t, expected, actual.Rows(), "Did not parse grid correctly")
+	})
+
+	t.Run("fail when unable to read", func(t *testing.T) {
+		_, err := Parse(iotest.ErrReader(errors.New("read error")))
+
+		assert.EqualError(t, err, "read error", "Did not fail when unable to read")
+	})
+
+	t.Run("create empty grid", func(t *testing.T) {
+		actual := New[int](2, 3)
+
+		assert.Equal(t, [][]int{{0, 0, 0}, {0, 0, 0}}, actual.Rows(), "Did not create grid")
+	})
+
+	t.Run("determine dimensions", func(t *testing.T) {
+		g := FromRows([][]byte{[]byte("ABC"), []byte("DEFG")})
+
+		assert.Equal(t, 2, g.Height(), "Did not determine height")
+		assert.Equal(t, 4, g.Width(), "Did not determine width")
+		assert.False(t, g.IsRectangular(), "Did not detect ragged rows")
+		assert.True(t, FromRows([][]byte{[]byte("AB"), []byte("CD")}).IsRectangular(), "Did not detect rectangular rows")
+	})
+
+	t.Run("check bounds per row", func(t *testing.T) {
+		g := FromRows([][]byte{[]byte("AB"), []byte("CDE")})
+
+		value, ok := g.At(Point{1, 2})
+		assert.True(t, ok, "Did not find cell in longer row")
+		assert.Equal(t, byte('E'), value, "Did not return cell value")
+
+		_, ok = g.At(Point{0, 2})
+		assert.False(t, ok, "Did not respect shorter row bounds")
+
+		_, ok = g.At(Point{-1, 0})
+		assert.False(t, ok, "Did not respect negative bounds")
+
+		_, ok = g.At(Point{2, 0})
+		assert.False(t, ok, "Did not respect height")
+	})
+
+	t.Run("set cells within bounds", func(t *testing.T) {
+		g := New[int](2, 2)
+
+		assert.True(t, g.Set(Point{1, 1}, 5), "Did not set cell")
+		assert.False(t, g.Set(Point{2, 1}, 5), "Did not reject out of bounds cell")
+		assert.Equal(t, [][]int{{0, 0}, {0, 5}}, g.Rows(), "Did not set cell correctly")
+	})
+
+	t.Run("scan rows and columns", func(t *testing.T) {
+		g := FromRows([][]byte{[]byte("ABC"), []byte("DE"), []byte("FGH")})
+
+		assert.Equal(t, []byte("DE"), g.Row(1), "Did not return row")
+		assert.Nil(t, g.Row(3), "Did not return nil for missing row")
+		assert.Equal(t, []byte("CH"), g.Column(2), "Did not return column")
+	})
+
+	t.Run("iterate all cells in order", func(t *testing.T) {
+		g := FromRows([][]byte{[]byte("AB"), []byte("C")})
+
+		points, cells := collect(g.All())
+
+		assert.Equal(t, []Point{{0, 0}, {0, 1}, {1, 0}}, points, "Did not iterate points in order")
+		assert.Equal(t, []byte("ABC"), cells, "Did not iterate cells in order")
+	})
+
+	t.Run("iterate orthogonal neighbours within bounds", func(t *testing.T) {
+		g := FromRows([][]byte{[]byte("ABC"), []byte("DEF")})
+
+		points, cells := collect(g.Neighbours4(Point{0, 0}))
+
+		assert.Equal(t, []Point{{0, 1}, {1, 0}}, points, "Did not iterate neighbours")
+		assert.Equal(t, []byte("BD"), cells, "Did not iterate neighbour cells")
+	})
+
+	t.Run("iterate surrounding neighbours within bounds", func(t *testing.T) {
+		g := FromRows([][]byte{[]byte("ABC"), []byte("DEF"), []byte("GHI")})
+
+		_, centre := collect(g.Neighbours8(Point{1, 1}))
+		_, corner := collect(g.Neighbours8(Point{2, 2}))
+
+		assert.Equal(t, []byte("ABCDFGHI"), centre, "Did not iterate surrounding cells")
+		assert.Equal(t, []byte("EFH"), corner, "Did not clamp surrounding cells")
+	})
+
+	t.Run("iterate box clamped to bounds", func(t *testing.T) {
+		g := FromRows([][]byte{[]byte("ABC"), []byte("DE"), []byte("GHI")})
+
+		_, cells := collect(g.Box(Point{-1, 1}, Point{1, 5}))
+
+		assert.Equal(t, []byte("BCE"), cells, "Did not iterate clamped box")
+	})
+
+	t.Run("stop iterating early", func(t *testing.T) {
+		g := FromRows([][]byte{[]byte("ABC"), []byte("DEF")})
+
+		var cells []byte
+		for _, cell := range g.All() {
+			cells = append(cells, cell)
+			if cell == 'B' {
+				break
+			}
+		}
+
+		assert.Equal(t, []byte("AB"), cells, "Did not stop iterating")
+	})
+
+	t.Run("transpose grid", func(t *testing.T) {
+		g := FromRows([][]byte{[]byte("ABC"), []byte("DEF")})
+
+		actual := g.Transpose().Rows()
+		expected := [][]byte{[]byte("AD"), []byte("BE"), []byte("CF")}
+
+		assert.Equal(t, expected, actual, "Did not transpose grid")
+	})
+
+	t.Run("pad ragged rows with the zero value when transposing", func(t *testing.T) {
+		g := FromRows([][]byte{[]byte("AB"), []byte("C"), []byte("DEF")})
+
+		actual := g.Transpose().Rows()
+		expected := [][]byte{[]byte("ACD"), []byte("B\x00E"), []byte("\x00\x00F")}
+
+		assert.Equal(t, expected, actual, "Did not pad ragged rows")
+	})
+
+	t.Run("pad ragged rows with the zero value when rotating", func(t *testing.T) {
+		g := FromRows([][]int{{1, 2}, {3}})
+
+		clockwise := g.RotateClockwise().Rows()
+		counterClockwise := g.RotateCounterClockwise().Rows()
+
+		assert.Equal(t, [][]int{{3, 1}, {0, 2}}, clockwise, "Did not pad ragged rows when rotating clockwise")
+		assert.Equal(t, [][]int{{2, 0}, {1, 3}}, counterClockwise, "Did not pad ragged rows when rotating counter clockwise")
+	})
+
+	t.Run("transpose empty grids", func(t *testing.T) {
+		assert.Empty(t, FromRows[byte](nil).Transpose().Rows(), "Did not transpose empty grid")
+	})
+
+	t.Run("rotate grid clockwise", func(t *testing.T) {
+		g := FromRows([][]byte{[]byte("ABC"), []byte("DEF")})
+
+		actual := g.RotateClockwise().Rows()
+		expected := [][]byte{[]byte("DA"), []byte("EB"), []byte("FC")}
+
+		assert.Equal(t, expected, actual, "Did not rotate grid clockwise")
+	})
+
+	t.Run("rotate grid counter clockwise", func(t *testing.T) {
+		g := FromRows([][]byte{[]byte("ABC"), []byte("DEF")})
+
+		actual := g.RotateCounterClockwise().Rows()
+		expected := [][]byte{[]byte("CF"), []byte("BE"), []byte("AD")}
+
+		assert.Equal(t, expected, actual, "Did not rotate grid counter clockwise")
+	})
+
+	t.Run("add points", func(t *testing.T) {
+		actual := Point{2, 3}.Add(Point{-1, 1})
+
+		assert.Equal(t, Point{1, 4}, actual, "Did not add points")
+	})
+
+}
+
+func BenchmarkGrid(b *testing.B) {
+
+	b.Run("parsing", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = Parse(strings.NewReader("467..114..\n...*......\n..35..633.\n......#..."))
+		}
+	})
+
+	b.Run("surrounding neighbours", func(b *testing.B) {
+		g := FromRows([][]byte{[]byte("ABC"), []byte("DEF"), []byte("GHI")})
+
+		for i := 0; i < b.N; i++ {
+			for range g.Neighbours8(Point{1, 1}) {
+			}
+		}
+	})
+
+}
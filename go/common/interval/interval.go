@@ -0,0 +1,159 @@
+package interval
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+type Interval struct {
+	Start int
+	End   int
+}
+
+func (i Interval) Len() int {
+	return max(i.End-i.Start, 0)
+}
+
+func (i Interval) IsEmpty() bool {
+	return i.End <= i.Start
+}
+
+func (i Interval) Contains(value int) bool {
+	return value >= i.Start && value < i.End
+}
+
+func (i Interval) Intersect(other Interval) (Interval, bool) {
+	intersection := Interval{max(i.Start, other.Start), min(i.End, other.End)}
+
+	return intersection, !intersection.IsEmpty()
+}
+
+func (i Interval) Shift(delta int) Interval {
+	return Interval{i.Start + delta, i.End + delta}
+}
+
+type Set struct {
+	intervals []Interval
+}
+
+func New(intervals ...Interval) Set {
+	var sorted []Interval
+	for _, i := range intervals {
+		if !i.IsEmpty() {
+			sorted = append(sorted, i)
+		}
+	}
+
+	slices.SortFunc(sorted, func(a Interval, b Interval) int {
+		return cmp.Compare(a.Start, b.Start)
+	})
+
+	var merged []Interval
+	for _, i := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && i.Start <= merged[last].End {
+			merged[last].End = max(merged[last].End, i.End)
+			continue
+		}
+
+		merged = append(merged, i)
+	}
+
+	return Set{merged}
+}
+
+func (s Set) Intervals() []Interval {
+	return slices.Clone(s.intervals)
+}
+
+func (s Set) IsEmpty() bool {
+	return len(s.intervals) == 0
+}
+
+func (s Set) Len() int {
+	total := 0
+	for _, i := range s.intervals {
+		total += i.Len()
+	}
+
+	return total
+}
+
+func (s Set) Min() (int, bool) {
+	if s.IsEmpty() {
+		return 0, false
+	}
+
+	return s.intervals[0].Start, true
+}
+
+func (s Set) Contains(value int) bool {
+	idx, found := slices.BinarySearchFunc(s.intervals, value, func(i Interval, value int) int {
+		if i.End <= value {
+			return -1
+		}
+		if i.Start > value {
+			return 1
+		}
+
+		return 0
+	})
+
+	return found && s.intervals[idx].Contains(value)
+}
+
+func (s Set) Merge(other Set) Set {
+	return New(append(s.Intervals(), other.intervals...)...)
+}
+
+func (s Set) Intersect(other Set) Set {
+	var intersections []Interval
+
+	for a, b := 0, 0; a < len(s.intervals) && b < len(other.intervals); {
+		if intersection, ok := s.intervals[a].Intersect(other.intervals[b]); ok {
+			intersections = append(intersections, intersection)
+		}
+
+		if s.intervals[a].End < other.intervals[b].End {
+			a++
+		} else {
+			b++
+		}
+	}
+
+	return New(intersections...)
+}
+
+func (s Set) Subtract(other Set) Set {
+	var remaining []Interval
+
+	for _, i := range s.intervals {
+		start := i.Start
+		for _, cut := range other.intervals {
+			if cut.End <= start || cut.Start >= i.End {
+				continue
+			}
+
+			remaining = append(remaining, Interval{start, cut.Start})
+			start = max(start, cut.End)
+		}
+
+		remaining = append(remaining, Interval{start, i.End})
+	}
+
+	return New(remaining...)
+}
+
+func (s Set) Split(at int) (below Set, above Set) {
+	return s.Intersect(New(Interval{math.MinInt, at})), s.Intersect(New(Interval{at, math.MaxInt}))
+}
+
+func (s Set) Shift(delta int) Set {
+	shifted := make([]Interval, len(s.intervals))
+	for idx, i := range s.intervals {
+		shifted[idx] = i.Shift(delta)
+	}
+
+	return Set{shifted}
+}
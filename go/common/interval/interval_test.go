@@ -0,0 +1,122 @@
+package interval
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIntervalShould(t *testing.T) {
+
+	t.Run("measure length", func(t *testing.T) {
+		assert.Equal(t, 5, Interval{3, 8}.Len(), "Did not measure length")
+		assert.Equal(t, 0, Interval{8, 3}.Len(), "Did not measure empty length")
+		assert.True(t, Interval{4, 4}.IsEmpty(), "Did not detect empty interval")
+	})
+
+	t.Run("contain values from start up to end", func(t *testing.T) {
+		assert.True(t, Interval{3, 8}.Contains(3), "Did not contain start")
+		assert.True(t, Interval{3, 8}.Contains(7), "Did not contain last value")
+		assert.False(t, Interval{3, 8}.Contains(8), "Did contain end")
+	})
+
+	tests := []struct {
+		a        Interval
+		b        Interval
+		expected Interval
+		ok       bool
+	}{
+		{Interval{0, 10}, Interval{5, 15}, Interval{5, 10}, true},
+		{Interval{5, 15}, Interval{0, 10}, Interval{5, 10}, true},
+		{Interval{0, 10}, Interval{2, 4}, Interval{2, 4}, true},
+		{Interval{0, 10}, Interval{10, 15}, Interval{10, 10}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("intersect %v with %v", test.a, test.b), func(t *testing.T) {
+			actual, ok := test.a.Intersect(test.b)
+
+			assert.Equal(t, test.ok, ok, "Did not determine overlap")
+			assert.Equal(t, test.expected, actual, "Did not intersect intervals")
+		})
+	}
+
+	t.Run("shift", func(t *testing.T) {
+		assert.Equal(t, Interval{-2, 3}, Interval{3, 8}.Shift(-5), "Did not shift interval")
+	})
+
+}
+
+func TestSetShould(t *testing.T) {
+
+	t.Run("merge overlapping and touching intervals", func(t *testing.T) {
+		actual := New(Interval{10, 12}, Interval{0, 3}, Interval{2, 5}, Interval{5, 7}, Interval{9, 9})
+
+		assert.Equal(t, []Interval{{0, 7}, {10, 12}}, actual.Intervals(), "Did not merge intervals")
+		assert.Equal(t, 9, actual.Len(), "Did not measure set length")
+	})
+
+	t.Run("find the minimum", func(t *testing.T) {
+		actual, ok := New(Interval{10, 12}, Interval{4, 5}).Min()
+		_, emptyOk := New().Min()
+
+		assert.True(t, ok, "Did not find minimum")
+		assert.Equal(t, 4, actual, "Did not find correct minimum")
+		assert.False(t, emptyOk, "Did find minimum of empty set")
+	})
+
+	t.Run("contain values", func(t *testing.T) {
+		set := New(Interval{0, 3}, Interval{10, 12})
+
+		assert.True(t, set.Contains(2), "Did not contain value")
+		assert.True(t, set.Contains(10), "Did not contain value")
+		assert.False(t, set.Contains(3), "Did contain value")
+		assert.False(t, set.Contains(12), "Did contain value")
+		assert.False(t, set.Contains(-1), "Did contain value")
+	})
+
+	t.Run("merge sets", func(t *testing.T) {
+		actual := New(Interval{0, 3}).Merge(New(Interval{3, 6}, Interval{8, 9}))
+
+		assert.Equal(t, []Interval{{0, 6}, {8, 9}}, actual.Intervals(), "Did not merge sets")
+	})
+
+	t.Run("intersect sets", func(t *testing.T) {
+		actual := New(Interval{0, 5}, Interval{8, 20}).Intersect(New(Interval{3, 10}, Interval{12, 14}, Interval{19, 30}))
+
+		assert.Equal(t, []Interval{{3, 5}, {8, 10}, {12, 14}, {19, 20}}, actual.Intervals(), "Did not intersect sets")
+	})
+
+	t.Run("subtract sets", func(t *testing.T) {
+		actual := New(Interval{0, 10}, Interval{20, 30}).Subtract(New(Interval{2, 4}, Interval{8, 22}, Interval{25, 26}))
+
+		assert.Equal(t, []Interval{{0, 2}, {4, 8}, {22, 25}, {26, 30}}, actual.Intervals(), "Did not subtract sets")
+	})
+
+	t.Run("split sets", func(t *testing.T) {
+		below, above := New(Interval{0, 10}, Interval{20, 30}).Split(25)
+
+		assert.Equal(t, []Interval{{0, 10}, {20, 25}}, below.Intervals(), "Did not split below")
+		assert.Equal(t, []Interval{{25, 30}}, above.Intervals(), "Did not split above")
+	})
+
+	t.Run("shift sets", func(t *testing.T) {
+		actual := New(Interval{0, 10}, Interval{20, 30}).Shift(5)
+
+		assert.Equal(t, []Interval{{5, 15}, {25, 35}}, actual.Intervals(), "Did not shift set")
+	})
+
+}
+
+func BenchmarkSet(b *testing.B) {
+
+	b.Run("set subtraction", func(b *testing.B) {
+		set := New(Interval{0, 10}, Interval{20, 30}, Interval{40, 50})
+		cut := New(Interval{5, 25}, Interval{45, 60})
+
+		for i := 0; i < b.N; i++ {
+			set.Subtract(cut)
+		}
+	})
+
+}
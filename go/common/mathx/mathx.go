@@ -0,0 +1,82 @@
+package mathx
+
+import (
+	"fmt"
+	"math/big"
+)
+
+type Congruence struct {
+	Residue *big.Int
+	Modulus *big.Int
+}
+
+func NewCongruence(residue int64, modulus int64) Congruence {
+	return Congruence{big.NewInt(residue), big.NewInt(modulus)}
+}
+
+func (c Congruence) String() string {
+	return fmt.Sprintf("x ≡ %s (mod %s)", c.Residue, c.Modulus)
+}
+
+func GCD(a *big.Int, b *big.Int) *big.Int {
+	return new(big.Int).GCD(nil, nil, new(big.Int).Abs(a), new(big.Int).Abs(b))
+}
+
+func LCM(values ...*big.Int) *big.Int {
+	result := big.NewInt(1)
+
+	for _, value := range values {
+		if value.Sign() == 0 {
+			return big.NewInt(0)
+		}
+
+		gcd := GCD(result, value)
+		result.Mul(result, new(big.Int).Quo(new(big.Int).Abs(value), gcd))
+	}
+
+	return result
+}
+
+func CRT(congruences ...Congruence) (Congruence, error) {
+	combined := NewCongruence(0, 1)
+
+	for _, congruence := range congruences {
+		if congruence.Modulus.Sign() <= 0 {
+			return Congruence{}, fmt.Errorf("invalid modulus [%s]", congruence.Modulus)
+		}
+
+		next, ok := combine(combined, congruence)
+		if !ok {
+			return Congruence{}, fmt.Errorf("no solution for [%s] and [%s]", combined, congruence)
+		}
+
+		combined = next
+	}
+
+	return combined, nil
+}
+
+func combine(a Congruence, b Congruence) (Congruence, bool) {
+	gcd := GCD(a.Modulus, b.Modulus)
+
+	difference := new(big.Int).Sub(b.Residue, a.Residue)
+	quotient, remainder := new(big.Int).QuoRem(difference, gcd, new(big.Int))
+	if remainder.Sign() != 0 {
+		return Congruence{}, false
+	}
+
+	reducedA := new(big.Int).Quo(a.Modulus, gcd)
+	reducedB := new(big.Int).Quo(b.Modulus, gcd)
+
+	factor := big.NewInt(0)
+	if reducedB.Cmp(big.NewInt(1)) != 0 {
+		factor.ModInverse(new(big.Int).Mod(reducedA, reducedB), reducedB)
+	}
+	factor.Mul(factor, quotient).Mod(factor, reducedB)
+
+	modulus := new(big.Int).Mul(a.Modulus, reducedB)
+	residue := new(big.Int).Mul(a.Modulus, factor)
+	residue.Add(residue, a.Residue).Mod(residue, modulus)
+
+	return Congruence{residue, modulus}, true
+}
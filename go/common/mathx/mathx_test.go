@@ -0,0 +1,183 @@
+package mathx
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func bruteForceCRT(congruences []Congruence) (int64, bool) {
+	modulus := int64(1)
+	for _, congruence := range congruences {
+		modulus = modulus / GCD(big.NewInt(modulus), congruence.Modulus).Int64() * congruence.Modulus.Int64()
+	}
+
+	for x := int64(0); x < modulus; x++ {
+		found := true
+		for _, congruence := range congruences {
+			m := congruence.Modulus.Int64()
+			if ((x-congruence.Residue.Int64())%m+m)%m != 0 {
+				found = false
+				break
+			}
+		}
+
+		if found {
+			return x, true
+		}
+	}
+
+	return -1, false
+}
+
+func TestGCDShould(t *testing.T) {
+
+	tests := []struct {
+		a        int64
+		b        int64
+		expected int64
+	}{
+		{12, 18, 6},
+		{18, 12, 6},
+		{17, 5, 1},
+		{0, 7, 7},
+		{-12, 18, 6},
+		{0, 0, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("find gcd of %d and %d", test.a, test.b), func(t *testing.T) {
+			actual := GCD(big.NewInt(test.a), big.NewInt(test.b))
+
+			assert.Equal(t, test.expected, actual.Int64(), "Did not find gcd")
+		})
+	}
+
+}
+
+func TestLCMShould(t *testing.T) {
+
+	tests := []struct {
+		values   []int64
+		expected string
+	}{
+		{[]int64{}, "1"},
+		{[]int64{4, 6}, "12"},
+		{[]int64{2, 3, 4, 5}, "60"},
+		{[]int64{-4, 6}, "12"},
+		{[]int64{4, 0}, "0"},
+		{[]int64{4611686018427387847, 4611686018427387817}, "21267647932558653302378126310941659999"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("find lcm of %v", test.values), func(t *testing.T) {
+			values := make([]*big.Int, len(test.values))
+			for i, value := range test.values {
+				values[i] = big.NewInt(value)
+			}
+
+			actual := LCM(values...)
+
+			assert.Equal(t, test.expected, actual.String(), "Did not find lcm")
+		})
+	}
+
+	t.Run("leave its arguments untouched", func(t *testing.T) {
+		a := big.NewInt(4)
+		b := big.NewInt(-6)
+
+		LCM(a, b)
+
+		assert.Equal(t, int64(4), a.Int64(), "Did modify first argument")
+		assert.Equal(t, int64(-6), b.Int64(), "Did modify second argument")
+	})
+
+}
+
+func TestCRTShould(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		congruences []Congruence
+		expected    Congruence
+	}{
+		{"solve nothing", nil, NewCongruence(0, 1)},
+		{"solve coprime moduli", []Congruence{NewCongruence(2, 3), NewCongruence(3, 5), NewCongruence(2, 7)}, NewCongruence(23, 105)},
+		{"solve shared factors", []Congruence{NewCongruence(2, 4), NewCongruence(4, 6)}, NewCongruence(10, 12)},
+		{"solve negative residues", []Congruence{NewCongruence(-1, 4), NewCongruence(-1, 6)}, NewCongruence(11, 12)},
+		{"solve repeated moduli", []Congruence{NewCongruence(3, 8), NewCongruence(11, 8)}, NewCongruence(3, 8)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := CRT(test.congruences...)
+
+			assert.Nil(t, err, "Did not solve congruences")
+			assert.Equal(t, test.expected.String(), actual.String(), "Did not solve congruences correctly")
+		})
+	}
+
+	t.Run("solve beyond 64 bits", func(t *testing.T) {
+		a := Congruence{big.NewInt(1), big.NewInt(4611686018427387847)}
+		b := Congruence{big.NewInt(2), big.NewInt(4611686018427387817)}
+
+		actual, err := CRT(a, b)
+
+		assert.Nil(t, err, "Did not solve congruences")
+		assert.Equal(t, "21267647932558653302378126310941659999", actual.Modulus.String(), "Did not combine moduli")
+		assert.Equal(t, int64(1), new(big.Int).Mod(actual.Residue, a.Modulus).Int64(), "Did not satisfy first congruence")
+		assert.Equal(t, int64(2), new(big.Int).Mod(actual.Residue, b.Modulus).Int64(), "Did not satisfy second congruence")
+	})
+
+	t.Run("agree with brute force for small moduli", func(t *testing.T) {
+		for m1 := int64(1); m1 <= 12; m1++ {
+			for m2 := int64(1); m2 <= 12; m2++ {
+				for r1 := int64(0); r1 < m1; r1++ {
+					for r2 := int64(0); r2 < m2; r2++ {
+						congruences := []Congruence{NewCongruence(r1, m1), NewCongruence(r2, m2)}
+
+						expected, ok := bruteForceCRT(congruences)
+						actual, err := CRT(congruences...)
+
+						assert.Equal(t, ok, err == nil, fmt.Sprintf("Did not agree on solvability of %v", congruences))
+						if ok {
+							assert.Equal(t, expected, actual.Residue.Int64(), fmt.Sprintf("Did not agree with brute force for %v", congruences))
+						}
+					}
+				}
+			}
+		}
+	})
+
+	failures := []struct {
+		name        string
+		congruences []Congruence
+		expected    string
+	}{
+		{"incompatible congruences", []Congruence{NewCongruence(1, 4), NewCongruence(2, 6)}, "no solution for [x ≡ 1 (mod 4)] and [x ≡ 2 (mod 6)]"},
+		{"zero moduli", []Congruence{NewCongruence(1, 0)}, "invalid modulus [0]"},
+		{"negative moduli", []Congruence{NewCongruence(1, -3)}, "invalid modulus [-3]"},
+	}
+
+	for _, test := range failures {
+		t.Run("fail for "+test.name, func(t *testing.T) {
+			_, err := CRT(test.congruences...)
+
+			assert.EqualError(t, err, test.expected, "Did not fail for "+test.name)
+		})
+	}
+
+}
+
+func BenchmarkCRT(b *testing.B) {
+
+	congruences := []Congruence{NewCongruence(2, 11309), NewCongruence(5, 13939), NewCongruence(7, 17621), NewCongruence(9, 19199)}
+
+	b.Run("solve congruences", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = CRT(congruences...)
+		}
+	})
+
+}
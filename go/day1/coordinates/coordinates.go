@@ -5,12 +5,113 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"unicode"
 )
 
-func CalculateTotal(path string, reader fileops.ReadableFile) (int, error) {
+const chunkSize = 4096
+
+type Mode int
+
+const (
+	Permissive Mode = iota
+	Lenient
+	Strict
+)
+
+type MissingDigitsError struct {
+	Lines []int
+}
+
+func (e *MissingDigitsError) Error() string {
+	lines := make([]string, len(e.Lines))
+	for i, line := range e.Lines {
+		lines[i] = strconv.Itoa(line)
+	}
+
+	return fmt.Sprintf("no calibration digits found on lines [%s]", strings.Join(lines, ", "))
+}
+
+type Option func(*settings)
+
+type settings struct {
+	mode      Mode
+	warn      func(line int)
+	workers   int
+	extractor TokenExtractor
+	combiner  Combiner
+}
+
+type chunk struct {
+	index     int
+	firstLine int
+	lines     []string
+	err       error
+}
+
+type chunkResult struct {
+	index   int
+	total   int
+	missing []int
+	err     error
+}
+
+func WithMode(mode Mode) Option {
+	return func(s *settings) {
+		s.mode = mode
+	}
+}
+
+func WithWarningHandler(handler func(line int)) Option {
+	return func(s *settings) {
+		s.warn = handler
+	}
+}
+
+func WithWorkers(workers int) Option {
+	return func(s *settings) {
+		if workers <= 0 {
+			workers = runtime.GOMAXPROCS(0)
+		}
+		s.workers = workers
+	}
+}
+
+func WithExtractor(extractor TokenExtractor, combiner Combiner) Option {
+	return func(s *settings) {
+		s.extractor = extractor
+		s.combiner = combiner
+	}
+}
+
+func ParseMode(name string) (Mode, error) {
+	switch name {
+	case "permissive":
+		return Permissive, nil
+	case "lenient":
+		return Lenient, nil
+	case "strict":
+		return Strict, nil
+	}
+
+	return -1, fmt.Errorf("unknown mode [%s]", name)
+}
+
+func CalculateTotal(path string, reader fileops.ReadableFile, opts ...Option) (int, error) {
+	config := settings{
+		mode:      Permissive,
+		warn:      func(int) {},
+		workers:   1,
+		extractor: CalibrationExtractor{},
+		combiner:  Combiner{10},
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
 	file, err := fileops.OpenFile(path, reader)
 	if err != nil {
 		return -1, err
@@ -22,37 +123,134 @@ func CalculateTotal(path string, reader fileops.ReadableFile) (int, error) {
 
 	scanner := bufio.NewScanner(file)
 
-	calibrationValuesTotal := 0
+	var result chunkResult
+	if config.workers > 1 {
+		result, err = evaluateConcurrently(scanner, config)
+	} else {
+		result, err = evaluateSequentially(scanner, config)
+	}
+	if err != nil {
+		return -1, err
+	}
+
+	return checkMissingDigits(result.total, result.missing, config)
+}
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		calibrationValuesTotal += combineFirstAndLastDigit(line)
+func evaluateSequentially(scanner *bufio.Scanner, config settings) (chunkResult, error) {
+	var result chunkResult
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		value, found := combineFirstAndLastDigit(scanner.Text(), config.extractor, config.combiner)
+		if !found {
+			result.missing = append(result.missing, lineNumber)
+		}
+
+		result.total += value
 	}
 
-	return calibrationValuesTotal, nil
+	if err := scanner.Err(); err != nil {
+		return chunkResult{}, err
+	}
+
+	return result, nil
 }
 
-func combineFirstAndLastDigit(line string) int {
-	firstDigit, lastDigit := 0, 0
-	line = replaceWordsWithDigits(line)
+func evaluateConcurrently(scanner *bufio.Scanner, config settings) (chunkResult, error) {
+	chunks := make(chan chunk, config.workers)
+	results := make(chan chunkResult, config.workers)
 
-	for i := 0; i < len(line); i++ {
-		firstDigit = isDigitOtherwiseZero(line[i])
+	go readChunks(scanner, chunks)
+
+	var wg sync.WaitGroup
+	for i := 0; i < config.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range chunks {
+				results <- evaluateChunk(c, config)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var ordered []chunkResult
+	for result := range results {
+		for len(ordered) <= result.index {
+			ordered = append(ordered, chunkResult{})
+		}
+		ordered[result.index] = result
+	}
+
+	var combined chunkResult
+	for _, result := range ordered {
+		if result.err != nil {
+			return chunkResult{}, result.err
+		}
 
-		if firstDigit > 0 {
-			break
+		combined.total += result.total
+		combined.missing = append(combined.missing, result.missing...)
+	}
+
+	return combined, nil
+}
+
+func readChunks(scanner *bufio.Scanner, chunks chan<- chunk) {
+	defer close(chunks)
+
+	current := chunk{index: 0, firstLine: 1}
+	for scanner.Scan() {
+		current.lines = append(current.lines, scanner.Text())
+
+		if len(current.lines) == chunkSize {
+			chunks <- current
+			current = chunk{index: current.index + 1, firstLine: current.firstLine + chunkSize}
+		}
+	}
+
+	current.err = scanner.Err()
+	if len(current.lines) > 0 || current.err != nil {
+		chunks <- current
+	}
+}
+
+func evaluateChunk(c chunk, config settings) chunkResult {
+	result := chunkResult{index: c.index, err: c.err}
+
+	for i, line := range c.lines {
+		value, found := combineFirstAndLastDigit(line, config.extractor, config.combiner)
+		if !found {
+			result.missing = append(result.missing, c.firstLine+i)
 		}
+
+		result.total += value
 	}
 
-	for i := len(line) - 1; i >= 0; i-- {
-		lastDigit = isDigitOtherwiseZero(line[i])
+	return result
+}
+
+func checkMissingDigits(total int, missing []int, config settings) (int, error) {
+	if len(missing) == 0 {
+		return total, nil
+	}
 
-		if lastDigit > 0 {
-			break
+	switch config.mode {
+	case Strict:
+		return -1, &MissingDigitsError{missing}
+	case Lenient:
+		for _, line := range missing {
+			config.warn(line)
 		}
 	}
 
-	return firstDigit*10 + lastDigit
+	return total, nil
+}
+
+func combineFirstAndLastDigit(line string, extractor TokenExtractor, combiner Combiner) (int, bool) {
+	return combiner.Combine(extractor.Extract(line))
 }
 
 func replaceWordsWithDigits(line string) string {
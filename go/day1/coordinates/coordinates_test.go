@@ -1,12 +1,15 @@
 package coordinates
 
 import (
+	"bufio"
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"io"
 	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 type MockFileReader struct {
@@ -53,10 +56,342 @@ func TestFailsWhenUnableToReadFile(t *testing.T) {
 	)
 }
 
+func TestFailsWhenUnableToReadEveryLine(t *testing.T) {
+	const fileName = "test_input.txt"
+
+	reader := io.MultiReader(strings.NewReader("1abc2\n"), iotest.ErrReader(errors.New("read error")))
+
+	mockReader := new(MockFileReader)
+	mockReader.On("Open", fileName).Return(io.NopCloser(reader), nil)
+
+	_, err := CalculateTotal(fileName, mockReader)
+
+	assert.EqualError(
+		t,
+		err,
+		"read error",
+		"Did not fail when unable to read every line",
+	)
+}
+
+func TestFailsForLinesThatAreTooLong(t *testing.T) {
+	const fileName = "test_input.txt"
+
+	lines := "1abc2\n" + strings.Repeat("a", 70_000) + "\n7\n3x4"
+
+	mockReader := new(MockFileReader)
+	mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+	_, err := CalculateTotal(fileName, mockReader, WithMode(Strict))
+
+	assert.ErrorIs(
+		t,
+		err,
+		bufio.ErrTooLong,
+		"Did not fail for a line that is too long",
+	)
+}
+
+func TestFailsConcurrentlyWhenUnableToReadEveryLine(t *testing.T) {
+	const fileName = "test_input.txt"
+
+	for _, lines := range []string{"1abc2\n", strings.Repeat("1abc2\n", chunkSize*3+7)} {
+		reader := io.MultiReader(strings.NewReader(lines), iotest.ErrReader(errors.New("read error")))
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(reader), nil)
+
+		_, err := CalculateTotal(fileName, mockReader, WithWorkers(4))
+
+		assert.EqualError(
+			t,
+			err,
+			"read error",
+			"Did not fail concurrently when unable to read every line",
+		)
+	}
+}
+
+func TestFailsConcurrentlyForLinesThatAreTooLong(t *testing.T) {
+	const fileName = "test_input.txt"
+
+	lines := strings.Repeat("1abc2\n", chunkSize+1) + strings.Repeat("a", 70_000) + "\n7\n3x4"
+
+	mockReader := new(MockFileReader)
+	mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+	_, err := CalculateTotal(fileName, mockReader, WithWorkers(4))
+
+	assert.ErrorIs(
+		t,
+		err,
+		bufio.ErrTooLong,
+		"Did not fail concurrently for a line that is too long",
+	)
+}
+
+func TestIgnoresLinesWithoutDigitsByDefault(t *testing.T) {
+	const fileName = "test_input.txt"
+	const lines = "7pqrstsixteen\n\nnothing here\nzoneight234"
+
+	mockReader := new(MockFileReader)
+	mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+	actual, err := CalculateTotal(fileName, mockReader)
+	expected := 76 + 14
+
+	assert.Nil(
+		t,
+		err,
+		"Did not ignore lines without digits",
+	)
+
+	assert.Equal(
+		t,
+		expected,
+		actual,
+		"Did not calculate the total correctly",
+	)
+}
+
+func TestWarnsAboutLinesWithoutDigitsInLenientMode(t *testing.T) {
+	const fileName = "test_input.txt"
+	const lines = "7pqrstsixteen\n\nnothing here\nzoneight234"
+
+	mockReader := new(MockFileReader)
+	mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+	var warnings []int
+	actual, err := CalculateTotal(
+		fileName,
+		mockReader,
+		WithMode(Lenient),
+		WithWarningHandler(func(line int) {
+			warnings = append(warnings, line)
+		}),
+	)
+
+	assert.Nil(
+		t,
+		err,
+		"Did not tolerate lines without digits",
+	)
+
+	assert.Equal(
+		t,
+		76+14,
+		actual,
+		"Did not calculate the total correctly",
+	)
+
+	assert.Equal(
+		t,
+		[]int{2, 3},
+		warnings,
+		"Did not warn about lines without digits",
+	)
+}
+
+func TestFailsForLinesWithoutDigitsInStrictMode(t *testing.T) {
+	const fileName = "test_input.txt"
+	const lines = "7pqrstsixteen\n\nnothing here\nzoneight234"
+
+	mockReader := new(MockFileReader)
+	mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+	_, err := CalculateTotal(fileName, mockReader, WithMode(Strict))
+
+	var missingDigitsErr *MissingDigitsError
+	assert.ErrorAs(
+		t,
+		err,
+		&missingDigitsErr,
+		"Did not fail with a missing digits error",
+	)
+
+	assert.Equal(
+		t,
+		[]int{2, 3},
+		missingDigitsErr.Lines,
+		"Did not report the lines without digits",
+	)
+
+	assert.EqualError(
+		t,
+		err,
+		"no calibration digits found on lines [2, 3]",
+		"Did not describe the lines without digits",
+	)
+}
+
+func TestSucceedsInStrictModeWhenAllLinesHaveDigits(t *testing.T) {
+	const fileName = "test_input.txt"
+	const lines = "7pqrstsixteen\neightwothree\nzoneight234"
+
+	mockReader := new(MockFileReader)
+	mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+	actual, err := CalculateTotal(fileName, mockReader, WithMode(Strict))
+	expected := 76 + 83 + 14
+
+	assert.Nil(
+		t,
+		err,
+		"Did not accept lines with digits",
+	)
+
+	assert.Equal(
+		t,
+		expected,
+		actual,
+		"Did not calculate the total correctly",
+	)
+}
+
+func TestCalculatesIdenticalTotalsConcurrently(t *testing.T) {
+	const fileName = "test_input.txt"
+	lines := generateLines(3*chunkSize + 17)
+
+	expectedReader := new(MockFileReader)
+	expectedReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+	var expectedWarnings []int
+	expected, _ := CalculateTotal(
+		fileName,
+		expectedReader,
+		WithMode(Lenient),
+		WithWarningHandler(func(line int) {
+			expectedWarnings = append(expectedWarnings, line)
+		}),
+	)
+
+	for _, workers := range []int{0, 2, 3, 8} {
+		t.Run(fmt.Sprintf("with %d workers", workers), func(t *testing.T) {
+			mockReader := new(MockFileReader)
+			mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+			var actualWarnings []int
+			actual, err := CalculateTotal(
+				fileName,
+				mockReader,
+				WithMode(Lenient),
+				WithWorkers(workers),
+				WithWarningHandler(func(line int) {
+					actualWarnings = append(actualWarnings, line)
+				}),
+			)
+
+			assert.Nil(
+				t,
+				err,
+				"Did not calculate the total concurrently",
+			)
+
+			assert.Equal(
+				t,
+				expected,
+				actual,
+				"Did not calculate the same total as the sequential path",
+			)
+
+			assert.Equal(
+				t,
+				expectedWarnings,
+				actualWarnings,
+				"Did not report the same warnings as the sequential path",
+			)
+		})
+	}
+}
+
+func TestFailsConcurrentlyInStrictModeWithOrderedLines(t *testing.T) {
+	const fileName = "test_input.txt"
+	lines := generateLines(2*chunkSize + 5)
+
+	mockReader := new(MockFileReader)
+	mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+	_, err := CalculateTotal(fileName, mockReader, WithMode(Strict), WithWorkers(4))
+
+	var missingDigitsErr *MissingDigitsError
+	assert.ErrorAs(
+		t,
+		err,
+		&missingDigitsErr,
+		"Did not fail with a missing digits error",
+	)
+
+	var expected []int
+	for line := 7; line <= 2*chunkSize+5; line += 7 {
+		expected = append(expected, line)
+	}
+
+	assert.Equal(
+		t,
+		expected,
+		missingDigitsErr.Lines,
+		"Did not report the lines without digits in order",
+	)
+}
+
+func TestEvaluatesChunk(t *testing.T) {
+	c := chunk{index: 3, firstLine: 10, lines: []string{"two1nine", "", "abcone2threexyz"}}
+
+	actual := evaluateChunk(c, settings{extractor: CalibrationExtractor{}, combiner: Combiner{10}})
+	expected := chunkResult{index: 3, total: 29 + 13, missing: []int{11}}
+
+	assert.Equal(
+		t,
+		expected,
+		actual,
+		"Did not evaluate the chunk correctly",
+	)
+}
+
+func TestParsesModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Mode
+	}{
+		{"permissive", Permissive},
+		{"lenient", Lenient},
+		{"strict", Strict},
+	}
+
+	for _, test := range tests {
+		actual, err := ParseMode(test.name)
+
+		assert.Nil(
+			t,
+			err,
+			"Did not parse mode",
+		)
+
+		assert.Equal(
+			t,
+			test.expected,
+			actual,
+			"Did not parse mode correctly",
+		)
+	}
+}
+
+func TestFailsToParseUnknownMode(t *testing.T) {
+	_, err := ParseMode("pedantic")
+	expected := "unknown mode [pedantic]"
+
+	assert.EqualError(
+		t,
+		err,
+		expected,
+		"Did not fail for unknown mode",
+	)
+}
+
 func TestCombinesWhenOnlyTwoDigitsAreProvided(t *testing.T) {
 	const line = "aXonebcdefghi9j"
 
-	actual := combineFirstAndLastDigit(line)
+	actual, _ := combineFirstAndLastDigit(line, CalibrationExtractor{}, Combiner{10})
 	expected := 19
 
 	assert.Equal(
@@ -70,7 +405,7 @@ func TestCombinesWhenOnlyTwoDigitsAreProvided(t *testing.T) {
 func TestCombineWhenMoreThanTwoDigitsAreProvided(t *testing.T) {
 	const line = "oid7afbk3ceeightao"
 
-	actual := combineFirstAndLastDigit(line)
+	actual, _ := combineFirstAndLastDigit(line, CalibrationExtractor{}, Combiner{10})
 	expected := 78
 
 	assert.Equal(
@@ -84,7 +419,7 @@ func TestCombineWhenMoreThanTwoDigitsAreProvided(t *testing.T) {
 func TestCombinesWhenOnlyOneDigitIsProvided(t *testing.T) {
 	const line = "gsFgsixasboeomNa"
 
-	actual := combineFirstAndLastDigit(line)
+	actual, _ := combineFirstAndLastDigit(line, CalibrationExtractor{}, Combiner{10})
 	expected := 66
 
 	assert.Equal(
@@ -95,6 +430,58 @@ func TestCombinesWhenOnlyOneDigitIsProvided(t *testing.T) {
 	)
 }
 
+func TestReportsWhenNoDigitsAreProvided(t *testing.T) {
+	const line = "no digits here"
+
+	_, found := combineFirstAndLastDigit(line, CalibrationExtractor{}, Combiner{10})
+
+	assert.False(
+		t,
+		found,
+		"Did not report the absence of digits",
+	)
+}
+
+func TestCalculatesTotalWithCustomExtractor(t *testing.T) {
+	const fileName = "test_input.txt"
+	const lines = "xaXIIbyIV\nMMXXIV and VII\nIIII"
+
+	mockReader := new(MockFileReader)
+	mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+	var warnings []int
+	actual, err := CalculateTotal(
+		fileName,
+		mockReader,
+		WithMode(Lenient),
+		WithExtractor(RomanExtractor{}, Combiner{100}),
+		WithWarningHandler(func(line int) {
+			warnings = append(warnings, line)
+		}),
+	)
+	expected := 12*100 + 4 + 2024*100 + 7
+
+	assert.Nil(
+		t,
+		err,
+		"Did not calculate the total with a custom extractor",
+	)
+
+	assert.Equal(
+		t,
+		expected,
+		actual,
+		"Did not calculate the total correctly",
+	)
+
+	assert.Equal(
+		t,
+		[]int{3},
+		warnings,
+		"Did not warn about lines without tokens",
+	)
+}
+
 func TestReplacesWordsWithDigits(t *testing.T) {
 	const input = "5five_sixseven8one1twozthreefoureight9nine0eightwozero"
 
@@ -182,6 +569,22 @@ func TestCanIdentifyNonDigit(t *testing.T) {
 	)
 }
 
+func generateLines(count int) string {
+	samples := []string{"two1nine", "eightwothree", "abcone2threexyz", "xtwone3four", "4nineeightseven2", "zoneight234"}
+
+	var builder strings.Builder
+	for i := 1; i <= count; i++ {
+		if i%7 == 0 {
+			builder.WriteString("no digits here")
+		} else {
+			builder.WriteString(samples[i%len(samples)])
+		}
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
 func BenchmarkTotalCalculation(b *testing.B) {
 	const fileName = "test_input.txt"
 	const lines = "7pqrstsixteen\neightwothree\nzoneight234"
@@ -194,6 +597,18 @@ func BenchmarkTotalCalculation(b *testing.B) {
 	}
 }
 
+func BenchmarkConcurrentTotalCalculation(b *testing.B) {
+	const fileName = "test_input.txt"
+	lines := generateLines(8 * chunkSize)
+
+	for i := 0; i < b.N; i++ {
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		_, _ = CalculateTotal(fileName, mockReader, WithWorkers(0))
+	}
+}
+
 func BenchmarkWordReplacement(b *testing.B) {
 	const input = "5five_sixseven8one1twozthreefoureight9nine0eightwozero"
 
@@ -215,6 +630,6 @@ func BenchmarkDigitCombination(b *testing.B) {
 	const line = "aXfivebcdefghi6j"
 
 	for i := 0; i < b.N; i++ {
-		_ = combineFirstAndLastDigit(line)
+		_, _ = combineFirstAndLastDigit(line, CalibrationExtractor{}, Combiner{10})
 	}
 }
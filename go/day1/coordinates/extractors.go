@@ -0,0 +1,172 @@
+package coordinates
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type TokenExtractor interface {
+	Extract(line string) []int
+}
+
+type Combiner struct {
+	Base int
+}
+
+func (c Combiner) Combine(values []int) (int, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+
+	return values[0]*c.Base + values[len(values)-1], true
+}
+
+type CalibrationExtractor struct{}
+
+func (CalibrationExtractor) Extract(line string) []int {
+	return DigitExtractor{}.Extract(replaceWordsWithDigits(line))
+}
+
+type DigitExtractor struct{}
+
+func (DigitExtractor) Extract(line string) []int {
+	var values []int
+
+	for i := 0; i < len(line); i++ {
+		if digit := isDigitOtherwiseZero(line[i]); digit > 0 {
+			values = append(values, digit)
+		}
+	}
+
+	return values
+}
+
+type WordExtractor struct{}
+
+func (WordExtractor) Extract(line string) []int {
+	nums := []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+
+	var values []int
+
+	for i := 0; i < len(line); i++ {
+		for idx, num := range nums {
+			if strings.HasPrefix(line[i:], num) {
+				values = append(values, idx+1)
+			}
+		}
+	}
+
+	return values
+}
+
+type HexExtractor struct{}
+
+func (HexExtractor) Extract(line string) []int {
+	var values []int
+
+	for i := 0; i < len(line); i++ {
+		value, err := strconv.ParseUint(line[i:i+1], 16, 8)
+		if err == nil {
+			values = append(values, int(value))
+		}
+	}
+
+	return values
+}
+
+type RomanExtractor struct{}
+
+func (RomanExtractor) Extract(line string) []int {
+	var values []int
+
+	start := -1
+	for i := 0; i <= len(line); i++ {
+		isNumeral := i < len(line) && strings.IndexByte("IVXLCDM", line[i]) >= 0
+
+		if isNumeral && start < 0 {
+			start = i
+		}
+
+		if !isNumeral && start >= 0 {
+			if value, err := fromRoman(line[start:i]); err == nil {
+				values = append(values, value)
+			}
+			start = -1
+		}
+	}
+
+	return values
+}
+
+func fromRoman(numeral string) (int, error) {
+	symbols := map[byte]int{'I': 1, 'V': 5, 'X': 10, 'L': 50, 'C': 100, 'D': 500, 'M': 1000}
+
+	value := 0
+	for i := 0; i < len(numeral); i++ {
+		current, ok := symbols[numeral[i]]
+		if !ok {
+			return -1, fmt.Errorf("invalid roman numeral [%s]", numeral)
+		}
+
+		if i+1 < len(numeral) && current < symbols[numeral[i+1]] {
+			value -= current
+		} else {
+			value += current
+		}
+	}
+
+	if value <= 0 || toRoman(value) != numeral {
+		return -1, fmt.Errorf("invalid roman numeral [%s]", numeral)
+	}
+
+	return value, nil
+}
+
+func toRoman(value int) string {
+	values := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	numerals := []string{"M", "CM", "D", "CD", "C", "XC", "L", "XL", "X", "IX", "V", "IV", "I"}
+
+	var builder strings.Builder
+	for i, step := range values {
+		for value >= step {
+			builder.WriteString(numerals[i])
+			value -= step
+		}
+	}
+
+	return builder.String()
+}
+
+type registeredExtractor struct {
+	extractor TokenExtractor
+	base      int
+}
+
+var extractors = map[string]registeredExtractor{
+	"calibration": {CalibrationExtractor{}, 10},
+	"digits":      {DigitExtractor{}, 10},
+	"words":       {WordExtractor{}, 10},
+	"hex":         {HexExtractor{}, 16},
+	"roman":       {RomanExtractor{}, 10},
+}
+
+func ExtractorNames() []string {
+	names := make([]string, 0, len(extractors))
+	for name := range extractors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func LookupExtractor(name string) (TokenExtractor, Combiner, error) {
+	registered, ok := extractors[name]
+	if !ok {
+		return nil, Combiner{}, fmt.Errorf("unknown extractor [%s]", name)
+	}
+
+	return registered.extractor, Combiner{registered.base}, nil
+}
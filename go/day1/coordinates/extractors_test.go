@@ -0,0 +1,122 @@
+package coordinates
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExtractorsShould(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		extractor TokenExtractor
+		line      string
+		expected  []int
+	}{
+		{"extract calibration digits and words", CalibrationExtractor{}, "xtwone3four0", []int{2, 1, 3, 4}},
+		{"extract decimal digits only", DigitExtractor{}, "xtwone3four05", []int{3, 5}},
+		{"extract overlapping words only", WordExtractor{}, "eightwo7nine", []int{8, 2, 9}},
+		{"extract hexadecimal digits", HexExtractor{}, "x0aG-F9", []int{0, 10, 15, 9}},
+		{"extract roman numerals", RomanExtractor{}, "aXIVbMCMXCIV-III", []int{14, 1994, 3}},
+		{"skip invalid roman numerals", RomanExtractor{}, "IIII VX IC IX", []int{9}},
+		{"extract nothing from an empty line", CalibrationExtractor{}, "", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := test.extractor.Extract(test.line)
+
+			assert.Equal(t, test.expected, actual, "Did not extract tokens correctly")
+		})
+	}
+
+}
+
+func TestCombinerShould(t *testing.T) {
+
+	tests := []struct {
+		base     int
+		values   []int
+		expected int
+		found    bool
+	}{
+		{10, []int{2, 1, 3, 4}, 24, true},
+		{10, []int{7}, 77, true},
+		{16, []int{15, 0}, 0xf0, true},
+		{100, []int{12, 4}, 1204, true},
+		{10, nil, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("combine %v in base %d", test.values, test.base), func(t *testing.T) {
+			actual, found := Combiner{test.base}.Combine(test.values)
+
+			assert.Equal(t, test.found, found, "Did not report whether values were found")
+			assert.Equal(t, test.expected, actual, "Did not combine values correctly")
+		})
+	}
+
+}
+
+func TestRomanNumeralConversionShould(t *testing.T) {
+
+	t.Run("convert valid numerals", func(t *testing.T) {
+		for value := 1; value < 4000; value++ {
+			actual, err := fromRoman(toRoman(value))
+
+			assert.Nil(t, err, "Did not convert numeral")
+			assert.Equal(t, value, actual, "Did not round trip numeral")
+		}
+	})
+
+	t.Run("fail for non canonical numerals", func(t *testing.T) {
+		_, err := fromRoman("IIII")
+		expected := "invalid roman numeral [IIII]"
+
+		assert.EqualError(t, err, expected, "Did not fail for non canonical numeral")
+	})
+
+}
+
+func TestExtractorLookupShould(t *testing.T) {
+
+	t.Run("look up registered extractors", func(t *testing.T) {
+		extractor, combiner, err := LookupExtractor("hex")
+
+		assert.Nil(t, err, "Did not look up extractor")
+		assert.Equal(t, HexExtractor{}, extractor, "Did not return the registered extractor")
+		assert.Equal(t, Combiner{16}, combiner, "Did not return the registered combiner")
+	})
+
+	t.Run("list registered extractors", func(t *testing.T) {
+		actual := ExtractorNames()
+		expected := []string{"calibration", "digits", "hex", "roman", "words"}
+
+		assert.Equal(t, expected, actual, "Did not list extractors")
+	})
+
+	t.Run("fail for unknown extractors", func(t *testing.T) {
+		_, _, err := LookupExtractor("klingon")
+		expected := "unknown extractor [klingon]"
+
+		assert.EqualError(t, err, expected, "Did not fail for unknown extractor")
+	})
+
+}
+
+func BenchmarkExtractors(b *testing.B) {
+
+	b.Run("calibration extraction", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = CalibrationExtractor{}.Extract("5five_sixseven8one1twozthreefoureight9nine0eightwozero")
+		}
+	})
+
+	b.Run("roman extraction", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = RomanExtractor{}.Extract("aXIVbMCMXCIV-III")
+		}
+	})
+
+}
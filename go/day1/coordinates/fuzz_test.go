@@ -0,0 +1,67 @@
+package coordinates
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func addTestdata(f *testing.F) {
+	paths, _ := filepath.Glob("../testdata/*.txt")
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err == nil {
+			f.Add(string(content))
+		}
+	}
+}
+
+func FuzzCalculateTotal(f *testing.F) {
+	addTestdata(f)
+
+	f.Fuzz(func(t *testing.T, input string) {
+		const fileName = "test_input.txt"
+
+		sequentialReader := new(MockFileReader)
+		sequentialReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(input)), nil)
+		concurrentReader := new(MockFileReader)
+		concurrentReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(input)), nil)
+
+		sequential, err := CalculateTotal(fileName, sequentialReader)
+		if err != nil {
+			t.Skip()
+		}
+
+		concurrent, err := CalculateTotal(fileName, concurrentReader, WithWorkers(4))
+
+		if sequential < 0 {
+			t.Errorf("total %d is negative", sequential)
+		}
+		if err != nil || concurrent != sequential {
+			t.Errorf("concurrent total %d (%v) differs from sequential total %d", concurrent, err, sequential)
+		}
+	})
+}
+
+func FuzzExtractors(f *testing.F) {
+	addTestdata(f)
+
+	f.Fuzz(func(t *testing.T, line string) {
+		for _, name := range ExtractorNames() {
+			extractor, combiner, _ := LookupExtractor(name)
+
+			values := extractor.Extract(line)
+			for _, value := range values {
+				if value < 0 {
+					t.Errorf("extractor %s produced negative value %d", name, value)
+				}
+			}
+
+			if total, found := combiner.Combine(values); found != (len(values) > 0) || total < 0 {
+				t.Errorf("extractor %s combined %v into %d", name, values, total)
+			}
+		}
+	})
+}
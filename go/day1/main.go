@@ -4,9 +4,12 @@ import (
 	"adventOfCode/common/fileops"
 	"adventOfCode/common/validation"
 	"adventOfCode/day1/coordinates"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"strings"
 )
 
 var osExit = os.Exit
@@ -14,14 +17,54 @@ var osExit = os.Exit
 func main() {
 	log.SetFlags(0)
 
-	path, err := validation.ExtractSingleArgIgnoringOthers(os.Args, 2)
+	flags := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	modeName := flags.String("mode", "permissive", "handling of lines without digits: permissive, lenient or strict")
+	workers := flags.Int("workers", 1, "number of concurrent workers, 0 for GOMAXPROCS")
+	extractorName := flags.String("extractor", "calibration", "token extractor: "+strings.Join(coordinates.ExtractorNames(), ", "))
+	base := flags.Int("base", 0, "base used to combine the first and last token, 0 for the extractor default")
+
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	mode, err := coordinates.ParseMode(*modeName)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	extractor, combiner, err := coordinates.LookupExtractor(*extractorName)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	if *base > 0 {
+		combiner.Base = *base
+	}
+
+	path, err := validation.ExtractSingleArgIgnoringOthers(flags.Args(), 1)
 	if err != nil {
 		log.Printf("Error: %s\n", err)
 		osExit(1)
 		return
 	}
 
-	total, err := coordinates.CalculateTotal(path, &fileops.FileReader{})
+	total, err := coordinates.CalculateTotal(
+		path,
+		&fileops.FileReader{},
+		coordinates.WithMode(mode),
+		coordinates.WithWorkers(*workers),
+		coordinates.WithExtractor(extractor, combiner),
+		coordinates.WithWarningHandler(func(line int) {
+			log.Printf("Warning: no calibration digits found on line %d\n", line)
+		}),
+	)
 	if err != nil {
 		log.Printf("Error: %s\n", err)
 		osExit(2)
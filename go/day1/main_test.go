@@ -39,6 +39,141 @@ func TestOutputsTotal(t *testing.T) {
 	)
 }
 
+func TestOutputsTotalInLenientMode(t *testing.T) {
+	const filename = "testdata/missing_digits.txt"
+	const expectedTotal = 19 + 66
+
+	os.Args = []string{"cmd", "--mode", "lenient", filename}
+
+	actualOut, actualCode, err := captureStdOut(main)
+	if err != nil {
+		assert.Fail(t, "Error: %s\n", err)
+	}
+
+	expectedOut := fmt.Sprintf("The sum of all calibration values is %d\n", expectedTotal)
+	expectedCode := 0
+
+	assert.Equal(
+		t,
+		expectedCode,
+		actualCode,
+		"Did not exit with the expected code",
+	)
+
+	assert.Equal(
+		t,
+		expectedOut,
+		actualOut,
+		"Did not output the total correctly",
+	)
+}
+
+func TestOutputsTotalWithWorkers(t *testing.T) {
+	const filename = "testdata/test_input.txt"
+	const expectedTotal = 19 + 66 + 78 + 29 + 83 + 14 + 76
+
+	os.Args = []string{"cmd", "--workers", "4", filename}
+
+	actualOut, actualCode, err := captureStdOut(main)
+	if err != nil {
+		assert.Fail(t, "Error: %s\n", err)
+	}
+
+	expectedOut := fmt.Sprintf("The sum of all calibration values is %d\n", expectedTotal)
+	expectedCode := 0
+
+	assert.Equal(
+		t,
+		expectedCode,
+		actualCode,
+		"Did not exit with the expected code",
+	)
+
+	assert.Equal(
+		t,
+		expectedOut,
+		actualOut,
+		"Did not output the total correctly",
+	)
+}
+
+func TestOutputsTotalWithHexExtractor(t *testing.T) {
+	const filename = "testdata/hex_input.txt"
+	const expectedTotal = 0xa9 + 0xf0 + 0x33
+
+	os.Args = []string{"cmd", "--extractor", "hex", filename}
+
+	actualOut, actualCode, err := captureStdOut(main)
+	if err != nil {
+		assert.Fail(t, "Error: %s\n", err)
+	}
+
+	expectedOut := fmt.Sprintf("The sum of all calibration values is %d\n", expectedTotal)
+	expectedCode := 0
+
+	assert.Equal(
+		t,
+		expectedCode,
+		actualCode,
+		"Did not exit with the expected code",
+	)
+
+	assert.Equal(
+		t,
+		expectedOut,
+		actualOut,
+		"Did not output the total correctly",
+	)
+}
+
+func TestFailsForUnknownExtractor(t *testing.T) {
+	const filename = "testdata/test_input.txt"
+
+	os.Args = []string{"cmd", "--extractor", "klingon", filename}
+
+	actualCode := captureErrorCode(main)
+	expectedCode := 1
+
+	assert.Equal(
+		t,
+		expectedCode,
+		actualCode,
+		"Did not exit with the expected code",
+	)
+}
+
+func TestFailsForMissingDigitsInStrictMode(t *testing.T) {
+	const filename = "testdata/missing_digits.txt"
+
+	os.Args = []string{"cmd", "--mode", "strict", filename}
+
+	actualCode := captureErrorCode(main)
+	expectedCode := 2
+
+	assert.Equal(
+		t,
+		expectedCode,
+		actualCode,
+		"Did not exit with the expected code",
+	)
+}
+
+func TestFailsForUnknownMode(t *testing.T) {
+	const filename = "testdata/test_input.txt"
+
+	os.Args = []string{"cmd", "--mode", "pedantic", filename}
+
+	actualCode := captureErrorCode(main)
+	expectedCode := 1
+
+	assert.Equal(
+		t,
+		expectedCode,
+		actualCode,
+		"Did not exit with the expected code",
+	)
+}
+
 func TestFailsWhenWrongArgs(t *testing.T) {
 	os.Args = []string{"cmd"}
 
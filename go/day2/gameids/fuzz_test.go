@@ -0,0 +1,63 @@
+package gameids
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func addTestdata(f *testing.F) {
+	content, err := os.ReadFile("../testdata/test_input.txt")
+	if err != nil {
+		return
+	}
+
+	f.Add(string(content))
+	for _, line := range strings.Split(string(content), "\n") {
+		f.Add(line)
+	}
+}
+
+func FuzzParseGame(f *testing.F) {
+	addTestdata(f)
+
+	f.Fuzz(func(t *testing.T, line string) {
+		game, err := ParseGame(line)
+		if err != nil {
+			return
+		}
+
+		for _, round := range game.Rounds {
+			for color, count := range round {
+				if count < 0 {
+					t.Errorf("color %s has negative count %d", color, count)
+				}
+			}
+		}
+
+		if power := game.Power(); power < 0 {
+			t.Errorf("power %d is negative", power)
+		}
+	})
+}
+
+func FuzzCalculateTotals(f *testing.F) {
+	addTestdata(f)
+
+	f.Fuzz(func(t *testing.T, input string) {
+		const fileName = "test_input.txt"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(input)), nil)
+
+		idTotal, minCubesTotal, err := CalculateTotals(fileName, mockReader)
+		if err != nil {
+			return
+		}
+
+		if idTotal < 0 || minCubesTotal < 0 {
+			t.Errorf("totals %d and %d are negative", idTotal, minCubesTotal)
+		}
+	})
+}
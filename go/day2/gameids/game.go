@@ -0,0 +1,56 @@
+package gameids
+
+import "slices"
+
+type Round map[string]int
+
+type Game struct {
+	ID     int
+	Rounds []Round
+}
+
+func (game Game) MinimumSet(colors []string) map[string]int {
+	highestPerColor := make(map[string]int)
+	for _, color := range colors {
+		highestPerColor[color] = 0
+	}
+
+	for _, round := range game.Rounds {
+		for color, quantity := range round {
+			if quantity >= highestPerColor[color] {
+				highestPerColor[color] = quantity
+			}
+		}
+	}
+
+	return highestPerColor
+}
+
+func (game Game) Power() int {
+	power := 1
+	for _, quantity := range game.MinimumSet(nil) {
+		power *= quantity
+	}
+
+	return power
+}
+
+func (game Game) IsPossible(bag Bag) bool {
+	return bag.Holds(game.MinimumSet(nil))
+}
+
+func (game Game) Colors() []string {
+	var colors []string
+
+	for _, round := range game.Rounds {
+		for color := range round {
+			if !slices.Contains(colors, color) {
+				colors = append(colors, color)
+			}
+		}
+	}
+
+	slices.Sort(colors)
+
+	return colors
+}
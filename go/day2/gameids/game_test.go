@@ -0,0 +1,106 @@
+package gameids
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGameShould(t *testing.T) {
+
+	game := Game{
+		ID: 2,
+		Rounds: []Round{
+			{"green": 7, "blue": 3},
+			{"blue": 20, "green": 4},
+			{"red": 6, "blue": 13, "green": 2},
+		},
+	}
+
+	t.Run("determine minimum set per color", func(t *testing.T) {
+		actual := game.MinimumSet(DefaultColors())
+		expected := map[string]int{"red": 6, "green": 7, "blue": 20}
+
+		assert.Equal(t, expected, actual, "Did not determine minimum set correctly")
+	})
+
+	t.Run("include expected colors missing from the game", func(t *testing.T) {
+		actual := game.MinimumSet([]string{"red", "gold"})
+		expected := map[string]int{"red": 6, "green": 7, "blue": 20, "gold": 0}
+
+		assert.Equal(t, expected, actual, "Did not determine minimum set correctly")
+	})
+
+	t.Run("determine power of minimum set", func(t *testing.T) {
+		actual := game.Power()
+		expected := 6 * 7 * 20
+
+		assert.Equal(t, expected, actual, "Did not determine power correctly")
+	})
+
+	t.Run("determine power over additional colors", func(t *testing.T) {
+		withGold := Game{3, []Round{{"green": 8, "blue": 6, "red": 20}, {"green": 13, "gold": 3}}}
+
+		actual := withGold.Power()
+		expected := 20 * 13 * 6 * 3
+
+		assert.Equal(t, expected, actual, "Did not determine power correctly")
+	})
+
+	t.Run("determine power over the colors present only", func(t *testing.T) {
+		withoutRed := Game{4, []Round{{"green": 3, "blue": 2}, {"blue": 5}}}
+
+		actual := withoutRed.Power()
+		expected := 3 * 5
+
+		assert.Equal(t, expected, actual, "Did not determine power correctly")
+	})
+
+	t.Run("determine game is impossible", func(t *testing.T) {
+		actual := game.IsPossible(DefaultBag())
+
+		assert.False(t, actual, "Did not determine game is impossible")
+	})
+
+	t.Run("determine game is possible", func(t *testing.T) {
+		actual := game.IsPossible(Bag{"red": 6, "green": 7, "blue": 20})
+
+		assert.True(t, actual, "Did not determine game is possible")
+	})
+
+	t.Run("list colors seen", func(t *testing.T) {
+		withCyan := Game{1, []Round{{"blue": 3, "red": 4}, {"red": 1, "cyan": 2}}}
+
+		actual := withCyan.Colors()
+		expected := []string{"blue", "cyan", "red"}
+
+		assert.Equal(t, expected, actual, "Did not list colors seen")
+	})
+
+}
+
+func BenchmarkGame(b *testing.B) {
+
+	game := Game{
+		ID: 2,
+		Rounds: []Round{
+			{"green": 7, "blue": 3},
+			{"blue": 20, "green": 4},
+			{"red": 6, "blue": 13, "green": 2},
+		},
+	}
+
+	b.Run("power determination", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = game.Power()
+		}
+	})
+
+	b.Run("possible game determination", func(b *testing.B) {
+		bag := DefaultBag()
+
+		for i := 0; i < b.N; i++ {
+			_ = game.IsPossible(bag)
+		}
+	})
+
+}
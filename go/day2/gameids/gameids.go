@@ -2,124 +2,182 @@ package gameids
 
 import (
 	"adventOfCode/common/fileops"
-	"bufio"
-	"errors"
 	"fmt"
-	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 )
 
-func CalculateTotals(path string, reader fileops.ReadableFile) (idTotal int, minCubesTotal int, err error) {
-	file, err := fileops.OpenFile(path, reader)
-	if err != nil {
-		return -1, -1, err
-	}
+type Bag map[string]int
 
-	defer func() {
-		_ = fileops.CloseFile(file)
-	}()
+func DefaultColors() []string {
+	return []string{"red", "green", "blue"}
+}
 
-	scanner := bufio.NewScanner(file)
+func DefaultBag() Bag {
+	return Bag{"red": 12, "green": 13, "blue": 14}
+}
+
+func ParseBag(spec string, colors []string) (Bag, error) {
+	bag := Bag{}
 
-	for scanner.Scan() {
-		line := scanner.Text()
+	for _, entry := range strings.Split(spec, ",") {
+		color, limit, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found {
+			return nil, fmt.Errorf("unable to parse bag entry [%s]", entry)
+		}
 
-		minCubes, err := minimumPossibleCubes(line)
+		color = strings.TrimSpace(color)
+		quantity, err := strconv.Atoi(strings.TrimSpace(limit))
 		if err != nil {
-			return -1, -1, err
+			return nil, fmt.Errorf("unable to parse limit in bag entry [%s]", entry)
 		}
 
-		minCubesTotal += minCubes
+		if _, ok := bag[color]; ok {
+			return nil, fmt.Errorf("duplicate color [%s] in bag", color)
+		}
 
-		gameId, err := possibleGameIdOrZero(line)
-		if err != nil {
-			return -1, -1, err
+		bag[color] = quantity
+	}
+
+	if err := bag.Validate(colors); err != nil {
+		return nil, err
+	}
+
+	defaults := DefaultBag()
+	for _, color := range colors {
+		if _, ok := bag[color]; ok {
+			continue
+		}
+
+		limit, ok := defaults[color]
+		if !ok {
+			return nil, fmt.Errorf("missing limit for color [%s] in bag", color)
 		}
 
-		idTotal += gameId
+		bag[color] = limit
 	}
 
-	return idTotal, minCubesTotal, nil
+	return bag, nil
 }
 
-func possibleGameIdOrZero(line string) (int, error) {
-	const maxRed = 12
-	const maxGreen = 13
-	const maxBlue = 14
+func (bag Bag) Validate(colors []string) error {
+	for color, limit := range bag {
+		if !slices.Contains(colors, color) {
+			return fmt.Errorf("unknown color [%s] in bag", color)
+		}
 
-	gameId, err := extractGameId(line)
-	if err != nil {
-		errMsg := fmt.Sprintf("unable to extract game id in line [%s]", line)
-		return -1, errors.New(errMsg)
+		if limit < 0 {
+			return fmt.Errorf("negative limit for color [%s] in bag", color)
+		}
 	}
 
-	highestPerColor, err := extractMaxColorValues(line)
-	if err != nil {
-		errMsg := fmt.Sprintf("unable to extract color values in line [%s]", line)
-		return -1, errors.New(errMsg)
+	return nil
+}
+
+func (bag Bag) Holds(highestPerColor map[string]int) bool {
+	for color, quantity := range highestPerColor {
+		if quantity > bag[color] {
+			return false
+		}
+	}
+
+	return true
+}
+
+type Option func(*settings)
+
+type settings struct {
+	bag          Bag
+	colors       []string
+	strictColors bool
+	colorsSeen   func(colors []string)
+	gamesParsed  func(games []Game)
+	spread       int
+}
+
+func WithBag(bag Bag) Option {
+	return func(s *settings) {
+		s.bag = bag
 	}
+}
 
-	if highestPerColor["red"] <= maxRed && highestPerColor["green"] <= maxGreen && highestPerColor["blue"] <= maxBlue {
-		return gameId, nil
+func WithColors(colors ...string) Option {
+	return func(s *settings) {
+		s.colors = colors
 	}
+}
 
-	return 0, nil
+func WithStrictColors() Option {
+	return func(s *settings) {
+		s.strictColors = true
+	}
 }
 
-func extractMaxColorValues(line string) (map[string]int, error) {
-	highestPerColor := map[string]int{
-		"red":   0,
-		"green": 0,
-		"blue":  0,
+func WithColorsSeenHandler(handler func(colors []string)) Option {
+	return func(s *settings) {
+		s.colorsSeen = handler
 	}
+}
 
-	revelations := strings.Split(line, ":")
-	if len(revelations) < 2 {
-		errMsg := fmt.Sprintf("unable to parse line [%s]", line)
-		return nil, errors.New(errMsg)
+func WithGamesParsedHandler(handler func(games []Game)) Option {
+	return func(s *settings) {
+		s.gamesParsed = handler
 	}
+}
 
-	revelationGroups := strings.Split(revelations[1], ";")
+func CalculateTotals(path string, reader fileops.ReadableFile, opts ...Option) (idTotal int, minCubesTotal int, err error) {
+	config := settings{bag: DefaultBag(), colors: DefaultColors(), colorsSeen: func([]string) {}, gamesParsed: func([]Game) {}}
+	for _, opt := range opts {
+		opt(&config)
+	}
 
-	cubesRegex := regexp.MustCompile(`(\d+)\s(green|blue|red)`)
+	if err := config.bag.Validate(config.colors); err != nil {
+		return -1, -1, err
+	}
 
-	for _, group := range revelationGroups {
-		matches := cubesRegex.FindAllStringSubmatch(group, -1)
-		for _, match := range matches {
-			quantity, _ := strconv.Atoi(match[1])
-			color := match[2]
+	games, err := extractGames(path, reader)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	config.gamesParsed(games)
 
-			if quantity > highestPerColor[color] {
-				highestPerColor[color] = quantity
+	var colorsSeen []string
+
+	for _, game := range games {
+		for _, color := range game.Colors() {
+			if config.strictColors && !slices.Contains(config.colors, color) {
+				return -1, -1, fmt.Errorf("unexpected color [%s] in game %d", color, game.ID)
 			}
-		}
-	}
 
-	return highestPerColor, nil
-}
+			if !slices.Contains(colorsSeen, color) {
+				colorsSeen = append(colorsSeen, color)
+			}
+		}
 
-func extractGameId(line string) (int, error) {
-	idRegex := regexp.MustCompile(`Game (\d+)`)
-	matches := idRegex.FindStringSubmatch(line)
+		minCubesTotal += game.Power()
 
-	if len(matches) < 2 {
-		return -1, errors.New("game id not found")
+		if game.IsPossible(config.bag) {
+			idTotal += game.ID
+		}
 	}
 
-	id, _ := strconv.Atoi(matches[1])
+	slices.Sort(colorsSeen)
+	config.colorsSeen(colorsSeen)
 
-	return id, nil
+	return idTotal, minCubesTotal, nil
 }
 
-func minimumPossibleCubes(line string) (int, error) {
-	highestPerColor, err := extractMaxColorValues(line)
+func extractGames(path string, reader fileops.ReadableFile) ([]Game, error) {
+	file, err := fileops.OpenFile(path, reader)
 	if err != nil {
-		errMsg := fmt.Sprintf("unable to extract color values in line [%s]", line)
-		return -1, errors.New(errMsg)
+		return nil, err
 	}
 
-	minCubes := highestPerColor["red"] * highestPerColor["green"] * highestPerColor["blue"]
+	defer func() {
+		_ = fileops.CloseFile(file)
+	}()
 
-	return minCubes, nil
+	return ParseGames(file)
 }
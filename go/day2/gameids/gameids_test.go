@@ -2,6 +2,7 @@ package gameids
 
 import (
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"io"
@@ -89,7 +90,22 @@ func TestTotalsCalculationShould(t *testing.T) {
 		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
 
 		_, _, err := CalculateTotals(fileName, mockReader)
-		expected := "unable to extract game id in line"
+		expected := "expected \"Game\""
+
+		var parseErr *ParseError
+		assert.ErrorAs(
+			t,
+			err,
+			&parseErr,
+			"Did not fail with a parse error",
+		)
+
+		assert.Equal(
+			t,
+			2,
+			parseErr.Line,
+			"Did not report the failing line",
+		)
 
 		assert.ErrorContains(
 			t,
@@ -109,7 +125,7 @@ func TestTotalsCalculationShould(t *testing.T) {
 		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
 
 		_, _, err := CalculateTotals(fileName, mockReader)
-		expected := "unable to extract color values in line"
+		expected := "line 2, column 27: missing \":\" after game id"
 
 		assert.ErrorContains(
 			t,
@@ -121,158 +137,185 @@ func TestTotalsCalculationShould(t *testing.T) {
 
 }
 
-func TestPossibleGameDeterminationShould(t *testing.T) {
-
-	t.Run("return id when game is possible", func(t *testing.T) {
-		const line = "Game 4: 3 red, 7 blue; 3 blue, 2 red, 2 green; 2 green, 1 red, 1 blue; 3 green, 5 blue, 5 red; 7 blue, 1 green, 1 red; 2 green, 7 blue"
+func TestBagShould(t *testing.T) {
 
-		actual, _ := possibleGameIdOrZero(line)
-		expected := 4
-
-		assert.Equal(
-			t,
-			expected,
-			actual,
-			"Did not extract id when game is possible",
-		)
-	})
+	t.Run("calculate total of possible game ids for a custom bag", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = `Game 1: 7 blue, 9 red, 1 green; 8 green; 10 green, 5 blue, 3 red; 11 blue, 5 red, 1 green
+					   Game 2: 7 green, 3 blue; 20 blue, 4 green; 6 red, 13 blue, 2 green
+					   Game 3: 11 blue, 3 red, 1 green; 15 red, 9 blue, 3 green; 11 blue, 4 red, 4 green`
 
-	t.Run("return 0 when game is not possible", func(t *testing.T) {
-		const line = "Game 2: 7 green, 3 blue; 20 blue, 4 green; 6 red, 13 blue, 2 green"
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
 
-		actual, _ := possibleGameIdOrZero(line)
-		expected := 0
+		actual, _, _ := CalculateTotals(fileName, mockReader, WithBag(Bag{"red": 15, "green": 10, "blue": 20}))
+		expected := 1 + 2 + 3
 
 		assert.Equal(
 			t,
 			expected,
 			actual,
-			"Did not return 0 when game is not possible",
+			"Did not calculate the game id total correctly",
 		)
 	})
 
-	t.Run("fail when unable to extract color values", func(t *testing.T) {
-		const line = "Game 2- 7 green, 3 blue; 20 blue, 4 green; 6 red, 13 blue, 2 green"
+	t.Run("fail to calculate totals for an invalid bag", func(t *testing.T) {
+		const fileName = "test_input.txt"
+
+		mockReader := new(MockFileReader)
 
-		_, err := possibleGameIdOrZero(line)
-		expected := "unable to extract color values in line"
+		_, _, err := CalculateTotals(fileName, mockReader, WithBag(Bag{"red": 12, "purple": 3}))
+		expected := "unknown color [purple] in bag"
 
-		assert.ErrorContains(
+		assert.EqualError(
 			t,
 			err,
 			expected,
-			"Did not handle game id parsing failure properly",
+			"Did not reject the invalid bag",
 		)
 	})
 
-	t.Run("fail when unable to extract game id", func(t *testing.T) {
-		const line = "Round 2: 7 green, 3 blue; 20 blue, 4 green; 6 red, 13 blue, 2 green"
-
-		_, err := possibleGameIdOrZero(line)
-		expected := "unable to extract game id in line"
+	t.Run("parse bag specification", func(t *testing.T) {
+		actual, _ := ParseBag("red=12, green=13,blue=14", DefaultColors())
+		expected := DefaultBag()
 
-		assert.ErrorContains(
+		assert.Equal(
 			t,
-			err,
 			expected,
-			"Did not handle game id parsing failure properly",
+			actual,
+			"Did not parse the bag correctly",
 		)
 	})
 
-}
-
-func TestMaxColorValuesExtractionShould(t *testing.T) {
+	t.Run("parse bag specification with spaces around the limits", func(t *testing.T) {
+		actual, err := ParseBag("red = 1, green= 2 ,blue =3", DefaultColors())
 
-	t.Run("extract maximum values per color", func(t *testing.T) {
-		const line = "Game 2: 7 green, 3 blue; 20 blue, 4 green; 6 red, 13 blue, 2 green"
+		assert.Nil(t, err, "Did not accept spaces around the limits")
+		assert.Equal(t, Bag{"red": 1, "green": 2, "blue": 3}, actual, "Did not parse the bag correctly")
+	})
 
-		actual, _ := extractMaxColorValues(line)
-		expected := map[string]int{"red": 6, "green": 7, "blue": 20}
+	t.Run("fill missing colors from the default bag", func(t *testing.T) {
+		actual, err := ParseBag("red=5", DefaultColors())
 
-		assert.Equal(
-			t,
-			expected,
-			actual,
-			"Did not extract max color values correctly",
-		)
+		assert.Nil(t, err, "Did not accept a partial bag")
+		assert.Equal(t, Bag{"red": 5, "green": 13, "blue": 14}, actual, "Did not fill the missing colors")
 	})
 
-	t.Run("fail when unable to split the line provided", func(t *testing.T) {
-		const line = "7 green, 3 blue; 20 blue, 4 green; 6 red, 13 blue, 2 green"
+	t.Run("fail for missing colors without a default limit", func(t *testing.T) {
+		_, err := ParseBag("red=5", []string{"red", "green", "blue", "yellow"})
 
-		_, err := extractMaxColorValues(line)
-		expected := "unable to parse line"
+		assert.EqualError(t, err, "missing limit for color [yellow] in bag", "Did not reject the partial bag")
+	})
 
-		assert.ErrorContains(
+	tests := []struct {
+		spec     string
+		expected string
+	}{
+		{"red=12,purple=3", "unknown color [purple] in bag"},
+		{"red=12,green", "unable to parse bag entry [green]"},
+		{"red=twelve", "unable to parse limit in bag entry [red=twelve]"},
+		{"red=-1", "negative limit for color [red] in bag"},
+		{"red=12,red=1", "duplicate color [red] in bag"},
+		{"red = 1, red=2", "duplicate color [red] in bag"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("fail to parse bag specification %s", test.spec), func(t *testing.T) {
+			_, err := ParseBag(test.spec, DefaultColors())
+
+			assert.EqualError(
+				t,
+				err,
+				test.expected,
+				"Did not reject the bag specification",
+			)
+		})
+	}
+
+	t.Run("treat missing colors as empty", func(t *testing.T) {
+		bag := Bag{"red": 5, "blue": 5}
+
+		actual := bag.Holds(map[string]int{"red": 1, "green": 1, "blue": 0})
+
+		assert.False(
 			t,
-			err,
-			expected,
-			"Did not extract max color values correctly",
+			actual,
+			"Did not treat missing colors as empty",
 		)
 	})
 
 }
 
-func TestGameIdExtractionShould(t *testing.T) {
+func TestColorsShould(t *testing.T) {
 
-	t.Run("extract game id", func(t *testing.T) {
-		const line = "Game 2: 7 green, 3 blue; 20 blue, 4 green; 6 red, 13 blue, 2 green"
+	t.Run("calculate totals over any colors present", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = `Game 1: 3 blue, 4 red, 2 purple; 1 red, 2 green, 6 blue; 2 green, 5 purple
+                       Game 2: 1 blue, 2 green; 3 green, 4 blue, 1 red; 1 green, 1 blue`
 
-		actual, _ := extractGameId(line)
-		expected := 2
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
 
-		assert.Equal(
-			t,
-			expected,
-			actual,
-			"Did not extract game id correctly",
+		var colorsSeen []string
+		actualIds, actualCubes, err := CalculateTotals(
+			fileName,
+			mockReader,
+			WithColors("red", "green", "blue", "purple"),
+			WithBag(Bag{"red": 12, "green": 13, "blue": 14, "purple": 4}),
+			WithColorsSeenHandler(func(colors []string) {
+				colorsSeen = colors
+			}),
 		)
+
+		assert.Nil(t, err, "Did not accept additional colors")
+		assert.Equal(t, 2, actualIds, "Did not calculate the game id total correctly")
+		assert.Equal(t, 4*2*6*5+1*3*4, actualCubes, "Did not calculate the minimum cubes total correctly")
+		assert.Equal(t, []string{"blue", "green", "purple", "red"}, colorsSeen, "Did not report the colors seen")
 	})
 
-	t.Run("fail to extract game id when not provided", func(t *testing.T) {
-		const line = "Round 1: 7 green, 3 blue; 20 blue, 4 green; 6 red, 13 blue, 2 green"
+	t.Run("report the games parsed", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "Game 1: 3 blue, 4 red\nGame 2: 1 blue; 2 green"
 
-		_, err := extractGameId(line)
-		expected := "game id not found"
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
 
-		assert.EqualError(
-			t,
-			err,
-			expected,
-			"Did not fail to extract game id properly",
-		)
-	})
+		var games []Game
+		_, _, err := CalculateTotals(fileName, mockReader, WithGamesParsedHandler(func(parsed []Game) {
+			games = parsed
+		}))
 
-}
+		expected := []Game{{1, []Round{{"blue": 3, "red": 4}}}, {2, []Round{{"blue": 1}, {"green": 2}}}}
+
+		assert.Nil(t, err, "Did not calculate totals")
+		assert.Equal(t, expected, games, "Did not report the games parsed")
+	})
 
-func TestMinPossibleCubesDeterminationShould(t *testing.T) {
+	t.Run("treat unexpected colors as absent from the bag", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = `Game 1: 3 blue, 4 red, 1 green; 1 orange
+                       Game 2: 1 blue, 2 green, 1 red`
 
-	t.Run("return minimum possible cubes", func(t *testing.T) {
-		const line = "Game 3: 8 green, 6 blue, 20 red; 5 blue, 4 red, 13 green; 5 green, 1 red"
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
 
-		actual, _ := minimumPossibleCubes(line)
-		expected := 20 * 13 * 6
+		actual, _, err := CalculateTotals(fileName, mockReader)
 
-		assert.Equal(
-			t,
-			expected,
-			actual,
-			"Did not calculate minimum possible cubes correctly",
-		)
+		assert.Nil(t, err, "Did not accept unexpected colors")
+		assert.Equal(t, 2, actual, "Did not calculate the game id total correctly")
 	})
 
-	t.Run("fail when unable to extract color values", func(t *testing.T) {
-		const line = "Game 2- 7 green, 3 blue; 20 blue, 4 green; 6 red, 13 blue, 2 green"
+	t.Run("fail for unexpected colors when strict", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = `Game 1: 3 blue, 4 red, 1 green; 1 orange`
 
-		_, err := minimumPossibleCubes(line)
-		expected := "unable to extract color values in line"
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
 
-		assert.ErrorContains(
-			t,
-			err,
-			expected,
-			"Did not handle game id parsing failure properly",
-		)
+		_, _, err := CalculateTotals(fileName, mockReader, WithStrictColors())
+		expected := "unexpected color [orange] in game 1"
+
+		assert.ErrorContains(t, err, expected, "Did not fail for unexpected colors")
 	})
 
 }
@@ -295,36 +338,4 @@ func BenchmarkTotalsCalculation(b *testing.B) {
 		}
 	})
 
-	b.Run("possible game determination", func(b *testing.B) {
-		const line = "Game 4: 3 red, 7 blue; 3 blue, 2 red, 2 green; 2 green, 1 red, 1 blue; 3 green, 5 blue, 5 red; 7 blue, 1 green, 1 red; 2 green, 7 blue"
-
-		for i := 0; i < b.N; i++ {
-			_, _ = possibleGameIdOrZero(line)
-		}
-	})
-
-	b.Run("max color values extraction", func(b *testing.B) {
-		const line = "Game 2: 7 green, 3 blue; 20 blue, 4 green; 6 red, 13 blue, 2 green"
-
-		for i := 0; i < b.N; i++ {
-			_, _ = extractMaxColorValues(line)
-		}
-	})
-
-	b.Run("game id extraction", func(b *testing.B) {
-		const line = "Game 2: 7 green, 3 blue; 20 blue, 4 green; 6 red, 13 blue, 2 green"
-
-		for i := 0; i < b.N; i++ {
-			_, _ = extractGameId(line)
-		}
-	})
-
-	b.Run("min possible cubes determination", func(b *testing.B) {
-		const line = "Game 3: 8 green, 6 blue, 20 red; 5 blue, 4 red, 13 green; 5 green, 1 red"
-
-		for i := 0; i < b.N; i++ {
-			_, _ = minimumPossibleCubes(line)
-		}
-	})
-
 }
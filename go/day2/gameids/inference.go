@@ -0,0 +1,182 @@
+package gameids
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+const confidenceThreshold = 1.92
+
+type Interval struct {
+	Low       int  `json:"low"`
+	High      int  `json:"high"`
+	Truncated bool `json:"truncated"`
+	Unbounded bool `json:"unbounded"`
+}
+
+type Estimate struct {
+	GameID        int                 `json:"gameId"`
+	Bag           Bag                 `json:"bag"`
+	Intervals     map[string]Interval `json:"intervals"`
+	LogLikelihood float64             `json:"logLikelihood"`
+}
+
+func WithSearchSpread(spread int) Option {
+	return func(s *settings) {
+		s.spread = spread
+	}
+}
+
+func InferBags(games []Game, opts ...Option) ([]Estimate, error) {
+	config := settings{spread: 20}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if config.spread < 0 {
+		return nil, fmt.Errorf("search spread must not be negative, got [%d]", config.spread)
+	}
+
+	estimates := make([]Estimate, len(games))
+	for i, game := range games {
+		estimates[i] = inferBag(game, config.spread)
+	}
+
+	return estimates, nil
+}
+
+func inferBag(game Game, spread int) Estimate {
+	colors := game.Colors()
+	lowest := game.MinimumSet(nil)
+
+	colorScores := make([][]float64, len(colors))
+	base := 0
+	for i, color := range colors {
+		colorScores[i] = colorLogLikelihoods(game, color, lowest[color], spread)
+		base += lowest[color]
+	}
+
+	prefixes := make([][]float64, len(colors)+1)
+	choices := make([][]int, len(colors)+1)
+	prefixes[0] = []float64{0}
+	for i, scores := range colorScores {
+		prefixes[i+1], choices[i+1] = maxPlusConvolve(prefixes[i], scores)
+	}
+
+	suffixes := make([][]float64, len(colors)+1)
+	suffixes[len(colors)] = []float64{0}
+	for i := len(colors) - 1; i >= 0; i-- {
+		suffixes[i], _ = maxPlusConvolve(colorScores[i], suffixes[i+1])
+	}
+
+	totalScores := make([]float64, len(prefixes[len(colors)]))
+	for offset := range totalScores {
+		totalScores[offset] = totalLogLikelihood(game, base+offset)
+	}
+
+	best := Estimate{GameID: game.ID, Bag: Bag{}, LogLikelihood: math.Inf(-1)}
+	bestOffset := 0
+	for offset, score := range prefixes[len(colors)] {
+		if logLikelihood := score + totalScores[offset]; logLikelihood > best.LogLikelihood {
+			best.LogLikelihood = logLikelihood
+			bestOffset = offset
+		}
+	}
+
+	for i := len(colors) - 1; i >= 0; i-- {
+		extra := choices[i+1][bestOffset]
+		best.Bag[colors[i]] = lowest[colors[i]] + extra
+		bestOffset -= extra
+	}
+
+	best.Intervals = make(map[string]Interval)
+	for i, color := range colors {
+		others, _ := maxPlusConvolve(prefixes[i], suffixes[i+1])
+
+		profile := make(map[int]float64)
+		for extra, score := range colorScores[i] {
+			profile[lowest[color]+extra] = math.Inf(-1)
+			for offset, otherScore := range others {
+				logLikelihood := score + otherScore + totalScores[extra+offset]
+				profile[lowest[color]+extra] = max(profile[lowest[color]+extra], logLikelihood)
+			}
+		}
+
+		best.Intervals[color] = profileInterval(profile, best.LogLikelihood, best.Bag[color], lowest[color]+spread)
+	}
+
+	return best
+}
+
+func colorLogLikelihoods(game Game, color string, lowest int, spread int) []float64 {
+	scores := make([]float64, spread+1)
+	for extra := range scores {
+		for _, round := range game.Rounds {
+			scores[extra] += logChoose(lowest+extra, round[color])
+		}
+	}
+
+	return scores
+}
+
+func totalLogLikelihood(game Game, total int) float64 {
+	logLikelihood := 0.0
+	for _, round := range game.Rounds {
+		drawn := 0
+		for _, quantity := range round {
+			drawn += quantity
+		}
+
+		logLikelihood -= logChoose(total, drawn)
+	}
+
+	return logLikelihood
+}
+
+func maxPlusConvolve(a []float64, b []float64) ([]float64, []int) {
+	scores := make([]float64, len(a)+len(b)-1)
+	choices := make([]int, len(scores))
+	for i := range scores {
+		scores[i] = math.Inf(-1)
+	}
+
+	for i, left := range a {
+		for j, right := range b {
+			if score := left + right; score > scores[i+j] || (score == scores[i+j] && j < choices[i+j]) {
+				scores[i+j] = score
+				choices[i+j] = j
+			}
+		}
+	}
+
+	return scores, choices
+}
+
+func profileInterval(profile map[int]float64, best float64, estimate int, upper int) Interval {
+	var plausible []int
+	for count, logLikelihood := range profile {
+		if best-logLikelihood <= confidenceThreshold {
+			plausible = append(plausible, count)
+		}
+	}
+	slices.Sort(plausible)
+
+	high := plausible[len(plausible)-1]
+
+	return Interval{plausible[0], high, high == upper, estimate == upper}
+}
+
+func logChoose(n int, k int) float64 {
+	if k < 0 || k > n {
+		return math.Inf(-1)
+	}
+
+	return logFactorial(n) - logFactorial(k) - logFactorial(n-k)
+}
+
+func logFactorial(n int) float64 {
+	value, _ := math.Lgamma(float64(n + 1))
+
+	return value
+}
@@ -0,0 +1,223 @@
+package gameids
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func gameLogLikelihood(game Game, bag Bag) float64 {
+	total := 0
+	for _, quantity := range bag {
+		total += quantity
+	}
+
+	logLikelihood := 0.0
+	for _, round := range game.Rounds {
+		drawn := 0
+		for color, quantity := range round {
+			if quantity > bag[color] {
+				return math.Inf(-1)
+			}
+
+			logLikelihood += logChoose(bag[color], quantity)
+			drawn += quantity
+		}
+
+		logLikelihood -= logChoose(total, drawn)
+	}
+
+	return logLikelihood
+}
+
+func bruteForceInferBag(game Game, spread int) Estimate {
+	colors := game.Colors()
+	lowest := game.MinimumSet(nil)
+
+	best := Estimate{GameID: game.ID, LogLikelihood: math.Inf(-1)}
+	profiles := make(map[string]map[int]float64)
+	for _, color := range colors {
+		profiles[color] = make(map[int]float64)
+	}
+
+	counts := make([]int, len(colors))
+	for i, color := range colors {
+		counts[i] = lowest[color]
+	}
+
+	for {
+		bag := Bag{}
+		for i, color := range colors {
+			bag[color] = counts[i]
+		}
+
+		logLikelihood := gameLogLikelihood(game, bag)
+		if logLikelihood > best.LogLikelihood {
+			best.Bag = bag
+			best.LogLikelihood = logLikelihood
+		}
+
+		for i, color := range colors {
+			if current, ok := profiles[color][counts[i]]; !ok || logLikelihood > current {
+				profiles[color][counts[i]] = logLikelihood
+			}
+		}
+
+		i := len(counts) - 1
+		for ; i >= 0 && counts[i] == lowest[colors[i]]+spread; i-- {
+			counts[i] = lowest[colors[i]]
+		}
+		if i < 0 {
+			break
+		}
+		counts[i]++
+	}
+
+	best.Intervals = make(map[string]Interval)
+	for _, color := range colors {
+		best.Intervals[color] = profileInterval(profiles[color], best.LogLikelihood, best.Bag[color], lowest[color]+spread)
+	}
+
+	return best
+}
+
+func TestInferenceShould(t *testing.T) {
+
+	t.Run("calculate likelihood of draws without replacement", func(t *testing.T) {
+		game := Game{1, []Round{{"red": 1}, {"red": 1}, {"blue": 1}}}
+
+		actual := gameLogLikelihood(game, Bag{"red": 2, "blue": 1})
+		expected := math.Log(2.0 / 3 * 2.0 / 3 * 1.0 / 3)
+
+		assert.InDelta(t, expected, actual, 1e-9, "Did not calculate likelihood correctly")
+	})
+
+	t.Run("treat impossible bags as having zero likelihood", func(t *testing.T) {
+		game := Game{1, []Round{{"red": 3}}}
+
+		actual := gameLogLikelihood(game, Bag{"red": 2})
+
+		assert.True(t, math.IsInf(actual, -1), "Did not treat impossible bag as having zero likelihood")
+	})
+
+	t.Run("estimate the minimum set for a single round", func(t *testing.T) {
+		game := Game{7, []Round{{"red": 4, "blue": 3}}}
+
+		actual := inferBag(game, 5)
+
+		assert.Equal(t, 7, actual.GameID, "Did not report the game id")
+		assert.Equal(t, Bag{"red": 4, "blue": 3}, actual.Bag, "Did not estimate the minimum set")
+		assert.InDelta(t, 0.0, actual.LogLikelihood, 1e-9, "Did not report the maximum likelihood")
+		assert.Equal(t, 4, actual.Intervals["red"].Low, "Did not determine the red interval")
+		assert.Equal(t, 3, actual.Intervals["blue"].Low, "Did not determine the blue interval")
+	})
+
+	t.Run("estimate a bag more likely than the minimum set", func(t *testing.T) {
+		game := Game{1, []Round{{"red": 1}, {"red": 1}, {"red": 1}, {"blue": 1}}}
+
+		actual := inferBag(game, 10)
+		minimum := gameLogLikelihood(game, game.MinimumSet(nil))
+
+		assert.Greater(t, actual.LogLikelihood, minimum, "Did not improve on the minimum set")
+		assert.Greater(t, actual.Bag["red"], actual.Bag["blue"], "Did not favour the most drawn color")
+		assert.LessOrEqual(t, actual.Intervals["red"].Low, actual.Bag["red"], "Did not include the estimate in its interval")
+		assert.GreaterOrEqual(t, actual.Intervals["red"].High, actual.Bag["red"], "Did not include the estimate in its interval")
+	})
+
+	t.Run("flag intervals reaching the search limit", func(t *testing.T) {
+		game := Game{1, []Round{{"red": 1}, {"blue": 1}}}
+
+		actual := inferBag(game, 3)
+
+		assert.True(t, actual.Intervals["red"].Truncated, "Did not flag truncated interval")
+		assert.Equal(t, 4, actual.Intervals["red"].High, "Did not stop at the search limit")
+	})
+
+	t.Run("flag estimates sitting on the search limit as unbounded", func(t *testing.T) {
+		game := Game{1, []Round{{"red": 6, "blue": 1}, {"red": 1, "blue": 6}}}
+
+		actual := inferBag(game, 10)
+
+		assert.Equal(t, Bag{"red": 16, "blue": 16}, actual.Bag, "Did not stop at the search limit")
+		assert.True(t, actual.Intervals["red"].Unbounded, "Did not flag the unbounded red estimate")
+		assert.True(t, actual.Intervals["blue"].Unbounded, "Did not flag the unbounded blue estimate")
+	})
+
+	t.Run("keep bounded estimates when the search spread changes", func(t *testing.T) {
+		game := Game{1, []Round{{"red": 4, "blue": 3}, {"red": 2, "blue": 5}}}
+
+		narrow := inferBag(game, 10)
+		wide := inferBag(game, 30)
+
+		assert.Equal(t, Bag{"red": 6, "blue": 8}, narrow.Bag, "Did not estimate the bag")
+		assert.Equal(t, narrow.Bag, wide.Bag, "Did not keep the bounded estimate")
+		assert.False(t, narrow.Intervals["red"].Unbounded, "Did not treat the red estimate as bounded")
+		assert.False(t, narrow.Intervals["blue"].Unbounded, "Did not treat the blue estimate as bounded")
+	})
+
+	t.Run("agree with an exhaustive search over every bag", func(t *testing.T) {
+		games := []Game{
+			{1, []Round{{"red": 1}, {"red": 1}, {"red": 1}, {"blue": 1}}},
+			{2, []Round{{"green": 8, "blue": 6, "red": 20}, {"blue": 5, "red": 4, "green": 13}, {"green": 5, "red": 1}}},
+			{3, []Round{{"red": 2, "blue": 1}, {"green": 3}, {"red": 1, "green": 1, "blue": 2}, {"blue": 1}}},
+		}
+
+		for _, game := range games {
+			expected := bruteForceInferBag(game, 8)
+			actual := inferBag(game, 8)
+
+			assert.Equal(t, expected.Bag, actual.Bag, "Did not agree on the bag for game %d", game.ID)
+			assert.InDelta(t, expected.LogLikelihood, actual.LogLikelihood, 1e-9, "Did not agree on the likelihood for game %d", game.ID)
+			assert.Equal(t, expected.Intervals, actual.Intervals, "Did not agree on the intervals for game %d", game.ID)
+		}
+	})
+
+	t.Run("infer bags with many colors quickly", func(t *testing.T) {
+		game := Game{1, []Round{{"red": 3, "green": 1, "blue": 4}, {"cyan": 1, "magenta": 5, "red": 9}, {"green": 2, "blue": 6, "cyan": 5}}}
+
+		actual := inferBag(game, 20)
+
+		assert.Len(t, actual.Bag, 5, "Did not estimate every color")
+		assert.Len(t, actual.Intervals, 5, "Did not determine every interval")
+	})
+
+	t.Run("infer bags for games that were already parsed", func(t *testing.T) {
+		games := []Game{{1, []Round{{"red": 4, "blue": 3}}}, {2, []Round{{"blue": 1}, {"green": 2}}}}
+
+		actual, err := InferBags(games, WithSearchSpread(5))
+
+		assert.Nil(t, err, "Error should be nil")
+		assert.Len(t, actual, 2, "Did not estimate every game")
+		assert.Equal(t, Bag{"blue": 3, "red": 4}, actual[0].Bag, "Did not estimate the first game")
+	})
+
+	t.Run("fail when the search spread is negative", func(t *testing.T) {
+		games := []Game{{1, []Round{{"red": 4, "blue": 3}}}}
+
+		actual, err := InferBags(games, WithSearchSpread(-1))
+
+		assert.Nil(t, actual, "Estimates should be nil")
+		assert.EqualError(t, err, "search spread must not be negative, got [-1]", "Did not reject the negative spread")
+	})
+
+}
+
+func BenchmarkInference(b *testing.B) {
+
+	b.Run("bag inference", func(b *testing.B) {
+		game := Game{3, []Round{{"green": 8, "blue": 6, "red": 20}, {"blue": 5, "red": 4, "green": 13}, {"green": 5, "red": 1}}}
+
+		for i := 0; i < b.N; i++ {
+			_ = inferBag(game, 10)
+		}
+	})
+
+	b.Run("bag inference with five colors", func(b *testing.B) {
+		game := Game{1, []Round{{"red": 3, "green": 1, "blue": 4}, {"cyan": 1, "magenta": 5, "red": 9}, {"green": 2, "blue": 6, "cyan": 5}}}
+
+		for i := 0; i < b.N; i++ {
+			_ = inferBag(game, 20)
+		}
+	})
+
+}
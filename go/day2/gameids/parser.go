@@ -0,0 +1,218 @@
+package gameids
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenNumber
+	tokenColon
+	tokenSemicolon
+	tokenComma
+	tokenInvalid
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	column int
+}
+
+type ParseError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+type lexer struct {
+	input string
+	pos   int
+}
+
+func (l *lexer) next() token {
+	l.consume(unicode.IsSpace)
+
+	start := l.pos
+	if start >= len(l.input) {
+		return token{tokenEOF, "", start + 1}
+	}
+
+	r, size := utf8.DecodeRuneInString(l.input[start:])
+	switch {
+	case r == ':':
+		l.pos += size
+		return token{tokenColon, ":", start + 1}
+	case r == ';':
+		l.pos += size
+		return token{tokenSemicolon, ";", start + 1}
+	case r == ',':
+		l.pos += size
+		return token{tokenComma, ",", start + 1}
+	case unicode.IsDigit(r) || (r == '-' && l.isDigitAt(start+size)):
+		l.pos += size
+		l.consume(unicode.IsDigit)
+		return token{tokenNumber, l.input[start:l.pos], start + 1}
+	case unicode.IsLetter(r):
+		l.consume(unicode.IsLetter)
+		return token{tokenWord, l.input[start:l.pos], start + 1}
+	}
+
+	l.pos += size
+	return token{tokenInvalid, string(r), start + 1}
+}
+
+func (l *lexer) isDigitAt(pos int) bool {
+	if pos >= len(l.input) {
+		return false
+	}
+
+	r, _ := utf8.DecodeRuneInString(l.input[pos:])
+	return unicode.IsDigit(r)
+}
+
+func (l *lexer) consume(accept func(rune) bool) {
+	for l.pos < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		if !accept(r) {
+			return
+		}
+		l.pos += size
+	}
+}
+
+type parser struct {
+	lexer   lexer
+	current token
+	line    int
+}
+
+func ParseGame(line string) (Game, error) {
+	return parseGame(line, 1)
+}
+
+func ParseGames(reader io.Reader) ([]Game, error) {
+	var games []Game
+
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		game, err := parseGame(scanner.Text(), lineNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		games = append(games, game)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return games, nil
+}
+
+func parseGame(line string, lineNumber int) (Game, error) {
+	p := &parser{lexer: lexer{input: line}, line: lineNumber}
+	p.advance()
+
+	if p.current.kind != tokenWord || p.current.text != "Game" {
+		return Game{}, p.fail("expected \"Game\"")
+	}
+	p.advance()
+
+	id, err := p.number("game id")
+	if err != nil {
+		return Game{}, err
+	}
+
+	if p.current.kind != tokenColon {
+		return Game{}, p.fail("missing \":\" after game id")
+	}
+	p.advance()
+
+	game := Game{ID: id}
+	for {
+		round, err := p.round()
+		if err != nil {
+			return Game{}, err
+		}
+
+		game.Rounds = append(game.Rounds, round)
+
+		if p.current.kind == tokenEOF {
+			return game, nil
+		}
+
+		if p.current.kind != tokenSemicolon {
+			return Game{}, p.fail("expected \",\", \";\" or end of line")
+		}
+		p.advance()
+	}
+}
+
+func (p *parser) round() (Round, error) {
+	round := Round{}
+
+	for {
+		count, err := p.number("cube count")
+		if err != nil {
+			return nil, err
+		}
+
+		if p.current.kind != tokenWord {
+			return nil, p.fail("expected color")
+		}
+
+		color := p.current.text
+		if _, duplicate := round[color]; duplicate {
+			return nil, p.fail(fmt.Sprintf("duplicate color [%s] in round", color))
+		}
+
+		round[color] = count
+		p.advance()
+
+		if p.current.kind != tokenComma {
+			return round, nil
+		}
+		p.advance()
+	}
+}
+
+func (p *parser) number(description string) (int, error) {
+	if p.current.kind != tokenNumber {
+		return -1, p.fail(fmt.Sprintf("expected %s", description))
+	}
+
+	value, err := strconv.Atoi(p.current.text)
+	if err != nil {
+		return -1, p.fail(fmt.Sprintf("invalid %s [%s]", description, p.current.text))
+	}
+
+	if value < 0 {
+		return -1, p.fail(fmt.Sprintf("negative %s [%s]", description, p.current.text))
+	}
+
+	p.advance()
+
+	return value, nil
+}
+
+func (p *parser) advance() {
+	p.current = p.lexer.next()
+}
+
+func (p *parser) fail(msg string) error {
+	return &ParseError{p.line, p.current.column, msg}
+}
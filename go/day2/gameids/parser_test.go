@@ -0,0 +1,161 @@
+package gameids
+
+import (
+	"bufio"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestGameParsingShould(t *testing.T) {
+
+	t.Run("parse game", func(t *testing.T) {
+		const line = "Game 2: 7 green, 3 blue; 20 blue, 4 green; 6 red, 13 blue, 2 green"
+
+		actual, err := ParseGame(line)
+		expected := Game{
+			ID: 2,
+			Rounds: []Round{
+				{"green": 7, "blue": 3},
+				{"blue": 20, "green": 4},
+				{"red": 6, "blue": 13, "green": 2},
+			},
+		}
+
+		assert.Nil(t, err, "Did not parse game")
+		assert.Equal(t, expected, actual, "Did not parse game correctly")
+	})
+
+	t.Run("parse game with surrounding whitespace and any colors", func(t *testing.T) {
+		const line = "\t  Game 17 :1 magenta,2 red ;  3 céladon  "
+
+		actual, err := ParseGame(line)
+		expected := Game{
+			ID: 17,
+			Rounds: []Round{
+				{"magenta": 1, "red": 2},
+				{"céladon": 3},
+			},
+		}
+
+		assert.Nil(t, err, "Did not parse game")
+		assert.Equal(t, expected, actual, "Did not parse game correctly")
+	})
+
+	tests := []struct {
+		name     string
+		line     string
+		expected string
+	}{
+		{"fail when game keyword is missing", "Round 1: 7 green", "line 1, column 1: expected \"Game\""},
+		{"fail when game id is missing", "Game : 7 green", "line 1, column 6: expected game id"},
+		{"fail when colon is missing", "Game 2- 7 green", "line 1, column 7: missing \":\" after game id"},
+		{"fail when game has no rounds", "Game 2:", "line 1, column 8: expected cube count"},
+		{"fail for negative counts", "Game 2: 7 green; -3 blue", "line 1, column 18: negative cube count [-3]"},
+		{"fail when color is missing", "Game 2: 7 green, 3; 1 red", "line 1, column 19: expected color"},
+		{"fail for duplicate colors in a round", "Game 2: 7 green, 3 blue, 1 green; 1 red", "line 1, column 28: duplicate color [green] in round"},
+		{"fail for unexpected characters", "Game 2: 7 green | 3 blue", "line 1, column 17: expected \",\", \";\" or end of line"},
+		{"fail for empty rounds", "Game 2: 7 green;; 3 blue", "line 1, column 17: expected cube count"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseGame(test.line)
+
+			assert.EqualError(t, err, test.expected, "Did not fail with a precise error")
+		})
+	}
+
+	t.Run("allow the same color in different rounds", func(t *testing.T) {
+		const line = "Game 2: 7 green; 3 green"
+
+		_, err := ParseGame(line)
+
+		assert.Nil(t, err, "Did not allow the same color in different rounds")
+	})
+
+}
+
+func TestGamesParsingShould(t *testing.T) {
+
+	t.Run("parse all games", func(t *testing.T) {
+		const lines = "Game 1: 3 blue, 4 red\nGame 2: 1 blue; 2 green"
+
+		actual, err := ParseGames(strings.NewReader(lines))
+		expected := []Game{
+			{1, []Round{{"blue": 3, "red": 4}}},
+			{2, []Round{{"blue": 1}, {"green": 2}}},
+		}
+
+		assert.Nil(t, err, "Did not parse games")
+		assert.Equal(t, expected, actual, "Did not parse games correctly")
+	})
+
+	t.Run("report the line of a failure", func(t *testing.T) {
+		const lines = "Game 1: 3 blue, 4 red\nGame 2: 1 blue; 2 green\nGame 3 1 blue"
+
+		_, err := ParseGames(strings.NewReader(lines))
+
+		var parseErr *ParseError
+		assert.ErrorAs(t, err, &parseErr, "Did not fail with a parse error")
+		assert.Equal(t, ParseError{3, 8, "missing \":\" after game id"}, *parseErr, "Did not report the failure position")
+	})
+
+	t.Run("fail when unable to read every line", func(t *testing.T) {
+		reader := io.MultiReader(strings.NewReader("Game 1: 3 blue, 4 red\n"), iotest.ErrReader(errors.New("read error")))
+
+		actual, err := ParseGames(reader)
+
+		assert.EqualError(t, err, "read error", "Did not fail when unable to read")
+		assert.Nil(t, actual, "Did return games read before the failure")
+	})
+
+	t.Run("fail for lines that are too long", func(t *testing.T) {
+		lines := "Game 1: " + strings.Repeat("1 red, ", bufio.MaxScanTokenSize/7) + "1 blue"
+
+		_, err := ParseGames(strings.NewReader(lines))
+
+		assert.ErrorIs(t, err, bufio.ErrTooLong, "Did not fail for a line that is too long")
+	})
+
+}
+
+func TestLexerShould(t *testing.T) {
+
+	t.Run("tokenize line", func(t *testing.T) {
+		l := lexer{input: "Game 12: -3 red;?"}
+
+		var actual []token
+		for tok := l.next(); tok.kind != tokenEOF; tok = l.next() {
+			actual = append(actual, tok)
+		}
+
+		expected := []token{
+			{tokenWord, "Game", 1},
+			{tokenNumber, "12", 6},
+			{tokenColon, ":", 8},
+			{tokenNumber, "-3", 10},
+			{tokenWord, "red", 13},
+			{tokenSemicolon, ";", 16},
+			{tokenInvalid, "?", 17},
+		}
+
+		assert.Equal(t, expected, actual, "Did not tokenize line correctly")
+	})
+
+}
+
+func BenchmarkGameParsing(b *testing.B) {
+
+	b.Run("game parsing", func(b *testing.B) {
+		const line = "Game 4: 3 red, 7 blue; 3 blue, 2 red, 2 green; 2 green, 1 red, 1 blue; 3 green, 5 blue, 5 red; 7 blue, 1 green, 1 red; 2 green, 7 blue"
+
+		for i := 0; i < b.N; i++ {
+			_, _ = ParseGame(line)
+		}
+	})
+
+}
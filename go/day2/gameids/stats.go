@@ -0,0 +1,207 @@
+package gameids
+
+import (
+	"adventOfCode/common/fileops"
+	"cmp"
+	"math"
+	"slices"
+)
+
+type Percentiles struct {
+	P25 int `json:"p25"`
+	P50 int `json:"p50"`
+	P75 int `json:"p75"`
+	P90 int `json:"p90"`
+}
+
+type ColorStats struct {
+	Color       string      `json:"color"`
+	Draws       int         `json:"draws"`
+	Min         int         `json:"min"`
+	Max         int         `json:"max"`
+	Mean        float64     `json:"mean"`
+	Percentiles Percentiles `json:"percentiles"`
+}
+
+type LimitingColor struct {
+	GameID int    `json:"gameId"`
+	Color  string `json:"color"`
+	Slack  int    `json:"slack"`
+}
+
+type Threshold struct {
+	Limit   int   `json:"limit"`
+	GameIDs []int `json:"gameIds"`
+}
+
+type LimitSensitivity struct {
+	Color      string      `json:"color"`
+	Limit      int         `json:"limit"`
+	Thresholds []Threshold `json:"thresholds"`
+}
+
+type Stats struct {
+	Colors         []ColorStats       `json:"colors"`
+	RoundsPerGame  map[int]int        `json:"roundsPerGame"`
+	LimitingColors []LimitingColor    `json:"limitingColors"`
+	Sensitivity    []LimitSensitivity `json:"sensitivity"`
+}
+
+func CalculateStats(path string, reader fileops.ReadableFile, opts ...Option) (Stats, error) {
+	config := settings{bag: DefaultBag(), colors: DefaultColors()}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if err := config.bag.Validate(config.colors); err != nil {
+		return Stats{}, err
+	}
+
+	games, err := extractGames(path, reader)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	return Analyse(games, config.bag), nil
+}
+
+func Analyse(games []Game, bag Bag) Stats {
+	return Stats{
+		Colors:         colorStats(games),
+		RoundsPerGame:  roundsPerGame(games),
+		LimitingColors: limitingColors(games, bag),
+		Sensitivity:    sensitivity(games, bag),
+	}
+}
+
+func colorStats(games []Game) []ColorStats {
+	drawsPerColor := make(map[string][]int)
+	for _, game := range games {
+		for _, round := range game.Rounds {
+			for color, quantity := range round {
+				drawsPerColor[color] = append(drawsPerColor[color], quantity)
+			}
+		}
+	}
+
+	var stats []ColorStats
+	for color, draws := range drawsPerColor {
+		slices.Sort(draws)
+
+		sum := 0
+		for _, draw := range draws {
+			sum += draw
+		}
+
+		stats = append(stats, ColorStats{
+			Color: color,
+			Draws: len(draws),
+			Min:   draws[0],
+			Max:   draws[len(draws)-1],
+			Mean:  float64(sum) / float64(len(draws)),
+			Percentiles: Percentiles{
+				P25: percentile(draws, 25),
+				P50: percentile(draws, 50),
+				P75: percentile(draws, 75),
+				P90: percentile(draws, 90),
+			},
+		})
+	}
+
+	slices.SortFunc(stats, func(a, b ColorStats) int {
+		return cmp.Compare(a.Color, b.Color)
+	})
+
+	return stats
+}
+
+func percentile(sorted []int, p int) int {
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+
+	return sorted[max(rank-1, 0)]
+}
+
+func roundsPerGame(games []Game) map[int]int {
+	distribution := make(map[int]int)
+	for _, game := range games {
+		distribution[len(game.Rounds)]++
+	}
+
+	return distribution
+}
+
+func limitingColors(games []Game, bag Bag) []LimitingColor {
+	var limiting []LimitingColor
+
+	for _, game := range games {
+		highestPerColor := game.MinimumSet(nil)
+
+		var colors []string
+		for color := range highestPerColor {
+			colors = append(colors, color)
+		}
+		slices.Sort(colors)
+
+		if len(colors) == 0 {
+			continue
+		}
+
+		tightest := LimitingColor{game.ID, colors[0], bag[colors[0]] - highestPerColor[colors[0]]}
+		for _, color := range colors[1:] {
+			if slack := bag[color] - highestPerColor[color]; slack < tightest.Slack {
+				tightest = LimitingColor{game.ID, color, slack}
+			}
+		}
+
+		limiting = append(limiting, tightest)
+	}
+
+	return limiting
+}
+
+func sensitivity(games []Game, bag Bag) []LimitSensitivity {
+	var colors []string
+	for color := range bag {
+		colors = append(colors, color)
+	}
+	slices.Sort(colors)
+
+	var sensitivities []LimitSensitivity
+	for _, color := range colors {
+		gamesPerLimit := make(map[int][]int)
+
+		for _, game := range games {
+			if !isPossibleIgnoring(game, bag, color) {
+				continue
+			}
+
+			threshold := game.MinimumSet([]string{color})[color]
+			gamesPerLimit[threshold] = append(gamesPerLimit[threshold], game.ID)
+		}
+
+		var limits []int
+		for limit := range gamesPerLimit {
+			limits = append(limits, limit)
+		}
+		slices.Sort(limits)
+
+		thresholds := make([]Threshold, len(limits))
+		for i, limit := range limits {
+			thresholds[i] = Threshold{limit, gamesPerLimit[limit]}
+		}
+
+		sensitivities = append(sensitivities, LimitSensitivity{color, bag[color], thresholds})
+	}
+
+	return sensitivities
+}
+
+func isPossibleIgnoring(game Game, bag Bag, ignored string) bool {
+	for color, quantity := range game.MinimumSet(nil) {
+		if color != ignored && quantity > bag[color] {
+			return false
+		}
+	}
+
+	return true
+}
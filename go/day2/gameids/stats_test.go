@@ -0,0 +1,112 @@
+package gameids
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStatsShould(t *testing.T) {
+
+	games := []Game{
+		{1, []Round{{"blue": 3, "red": 4}, {"red": 1, "green": 2, "blue": 6}, {"green": 2}}},
+		{2, []Round{{"blue": 1, "green": 2}, {"green": 3, "blue": 4, "red": 1}, {"green": 1, "blue": 1}}},
+		{3, []Round{{"green": 8, "blue": 6, "red": 20}, {"blue": 5, "red": 4, "green": 13}, {"green": 5, "red": 1}}},
+		{4, []Round{{"green": 1, "red": 3, "blue": 6}, {"green": 3, "red": 6}, {"green": 3, "blue": 15, "red": 14}}},
+		{5, []Round{{"red": 6, "blue": 1, "green": 3}, {"blue": 2, "red": 1, "green": 2}}},
+	}
+
+	t.Run("summarise draws per color", func(t *testing.T) {
+		actual := Analyse(games, DefaultBag()).Colors
+		expected := []ColorStats{
+			{"blue", 11, 1, 15, 50.0 / 11, Percentiles{1, 4, 6, 6}},
+			{"green", 13, 1, 13, 48.0 / 13, Percentiles{2, 3, 3, 8}},
+			{"red", 11, 1, 20, 61.0 / 11, Percentiles{1, 4, 6, 14}},
+		}
+
+		assert.Equal(t, expected, actual, "Did not summarise draws correctly")
+	})
+
+	t.Run("determine distribution of rounds per game", func(t *testing.T) {
+		actual := Analyse(games, DefaultBag()).RoundsPerGame
+		expected := map[int]int{2: 1, 3: 4}
+
+		assert.Equal(t, expected, actual, "Did not determine rounds distribution correctly")
+	})
+
+	t.Run("determine most limiting color per game", func(t *testing.T) {
+		actual := Analyse(games, DefaultBag()).LimitingColors
+		expected := []LimitingColor{
+			{1, "blue", 8},
+			{2, "blue", 10},
+			{3, "red", -8},
+			{4, "red", -2},
+			{5, "red", 6},
+		}
+
+		assert.Equal(t, expected, actual, "Did not determine limiting colors correctly")
+	})
+
+	t.Run("determine limits at which games become possible", func(t *testing.T) {
+		actual := Analyse(games, DefaultBag()).Sensitivity
+		expected := []LimitSensitivity{
+			{"blue", 14, []Threshold{{2, []int{5}}, {4, []int{2}}, {6, []int{1}}}},
+			{"green", 13, []Threshold{{2, []int{1}}, {3, []int{2, 5}}}},
+			{"red", 12, []Threshold{{1, []int{2}}, {4, []int{1}}, {6, []int{5}}, {20, []int{3}}}},
+		}
+
+		assert.Equal(t, expected, actual, "Did not determine limit sensitivity correctly")
+	})
+
+	t.Run("determine percentiles by nearest rank", func(t *testing.T) {
+		sorted := []int{15, 20, 35, 40, 50}
+
+		assert.Equal(t, 15, percentile(sorted, 0), "Did not determine lowest percentile")
+		assert.Equal(t, 20, percentile(sorted, 30), "Did not determine 30th percentile")
+		assert.Equal(t, 35, percentile(sorted, 50), "Did not determine median")
+		assert.Equal(t, 50, percentile(sorted, 100), "Did not determine highest percentile")
+	})
+
+	t.Run("calculate stats from file", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "Game 1: 3 blue, 4 red\nGame 2: 1 blue; 2 green"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		actual, err := CalculateStats(fileName, mockReader)
+
+		assert.Nil(t, err, "Did not calculate stats")
+		assert.Equal(t, map[int]int{1: 1, 2: 1}, actual.RoundsPerGame, "Did not calculate stats correctly")
+	})
+
+	t.Run("fail when unable to read file", func(t *testing.T) {
+		const fileName = "test_input.txt"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader("")), errors.New("file open error"))
+
+		_, err := CalculateStats(fileName, mockReader)
+		expected := "file open error"
+
+		assert.EqualError(t, err, expected, "Did not fail when unable to read file")
+	})
+
+}
+
+func BenchmarkStats(b *testing.B) {
+
+	b.Run("analysis", func(b *testing.B) {
+		games := []Game{
+			{1, []Round{{"blue": 3, "red": 4}, {"red": 1, "green": 2, "blue": 6}, {"green": 2}}},
+			{2, []Round{{"blue": 1, "green": 2}, {"green": 3, "blue": 4, "red": 1}, {"green": 1, "blue": 1}}},
+		}
+
+		for i := 0; i < b.N; i++ {
+			_ = Analyse(games, DefaultBag())
+		}
+	})
+
+}
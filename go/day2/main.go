@@ -4,30 +4,152 @@ import (
 	"adventOfCode/common/fileops"
 	"adventOfCode/common/validation"
 	"adventOfCode/day2/gameids"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"slices"
+	"strings"
 )
 
 var osExit = os.Exit
 
+type bagFlags struct {
+	bag    *string
+	colors *string
+}
+
 func main() {
 	log.SetFlags(0)
 
-	path, err := validation.ExtractSingleArgIgnoringOthers(os.Args, 2)
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] == "stats" {
+		runStats(args[1:])
+		return
+	}
+
+	if len(args) > 0 && args[0] == "query" {
+		runQuery(args[1:])
+		return
+	}
+
+	runTotals(args)
+}
+
+func runTotals(args []string) {
+	flags, bagSpec := newFlagSet()
+	strictColors := flags.Bool("strict-colors", false, "fail when an unexpected color appears")
+	showColors := flags.Bool("show-colors", false, "report the colors seen across all games")
+	infer := flags.Bool("infer", false, "estimate the most likely bag contents per game")
+	spread := flags.Int("spread", 20, "cubes above the minimum set searched per color when inferring")
+
+	path, bag, colors, err := parseArgs(flags, bagSpec, args)
 	if err != nil {
 		log.Printf("Error: %s\n", err)
 		osExit(1)
 		return
 	}
 
-	idsTotal, minCubesTotal, err := gameids.CalculateTotals(path, &fileops.FileReader{})
+	var colorsSeen []string
+	var games []gameids.Game
+	opts := []gameids.Option{
+		gameids.WithBag(bag),
+		gameids.WithColors(colors...),
+		gameids.WithColorsSeenHandler(func(seen []string) {
+			colorsSeen = seen
+		}),
+		gameids.WithGamesParsedHandler(func(parsed []gameids.Game) {
+			games = parsed
+		}),
+	}
+	if *strictColors {
+		opts = append(opts, gameids.WithStrictColors())
+	}
+
+	idsTotal, minCubesTotal, err := gameids.CalculateTotals(path, &fileops.FileReader{}, opts...)
 	if err != nil {
 		log.Printf("Error: %s\n", err)
 		osExit(2)
 		return
 	}
 
+	var estimates []gameids.Estimate
+	if *infer {
+		estimates, err = gameids.InferBags(games, gameids.WithSearchSpread(*spread))
+		if err != nil {
+			log.Printf("Error: %s\n", err)
+			osExit(1)
+			return
+		}
+	}
+
 	fmt.Printf("The sum of all possible game ids is %d\n", idsTotal)
 	fmt.Printf("The sum of the minimum posisble cubes is %d\n", minCubesTotal)
+
+	if *showColors {
+		fmt.Printf("The colors seen are %s\n", strings.Join(colorsSeen, ", "))
+	}
+
+	if *infer {
+		printInference(estimates)
+	}
+}
+
+func printInference(estimates []gameids.Estimate) {
+	for _, estimate := range estimates {
+		var colors []string
+		for color := range estimate.Bag {
+			colors = append(colors, color)
+		}
+		slices.Sort(colors)
+
+		parts := make([]string, len(colors))
+		for i, color := range colors {
+			interval := estimate.Intervals[color]
+			upper := ""
+			if interval.Truncated {
+				upper = "+"
+			}
+
+			relation := "="
+			if interval.Unbounded {
+				relation = ">="
+			}
+
+			parts[i] = fmt.Sprintf("%s%s%d (%d-%d%s)", color, relation, estimate.Bag[color], interval.Low, interval.High, upper)
+		}
+
+		fmt.Printf("The most likely bag for game %d is %s\n", estimate.GameID, strings.Join(parts, ", "))
+	}
+}
+
+func newFlagSet() (*flag.FlagSet, bagFlags) {
+	flags := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+
+	return flags, bagFlags{
+		bag:    flags.String("bag", "red=12,green=13,blue=14", "cube limits per color"),
+		colors: flags.String("colors", strings.Join(gameids.DefaultColors(), ","), "expected cube colors"),
+	}
+}
+
+func parseArgs(flags *flag.FlagSet, bagSpec bagFlags, args []string) (string, gameids.Bag, []string, error) {
+	if err := flags.Parse(args); err != nil {
+		return "", nil, nil, err
+	}
+
+	colors := strings.Split(*bagSpec.colors, ",")
+
+	bag, err := gameids.ParseBag(*bagSpec.bag, colors)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	path, err := validation.ExtractSingleArgIgnoringOthers(flags.Args(), 1)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	return path, bag, colors, nil
 }
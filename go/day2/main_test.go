@@ -1,7 +1,9 @@
 package main
 
 import (
+	"adventOfCode/day2/gameids"
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
@@ -70,6 +72,318 @@ func TestApplicationShould(t *testing.T) {
 		)
 	})
 
+	t.Run("output the minimum possible cubes total for additional colors", func(t *testing.T) {
+		const filename = "testdata/test_input.txt"
+		const expectedTotal = 48 + 12 + 1560 + 630 + 36
+
+		os.Args = []string{"cmd", "--colors", "red,green,blue,yellow", "--bag", "red=12,green=13,blue=14,yellow=1", filename}
+
+		actualOut, actualCode, err := captureStdOut(main)
+		if err != nil {
+			assert.Fail(t, "Error: %s\n", err)
+		}
+
+		expectedOut := fmt.Sprintf("The sum of the minimum posisble cubes is %d\n", expectedTotal)
+		expectedCode := 0
+
+		assert.Equal(
+			t,
+			expectedCode,
+			actualCode,
+			"Did not exit with the expected code",
+		)
+
+		assert.Contains(
+			t,
+			actualOut,
+			expectedOut,
+			"Did not output the total correctly",
+		)
+	})
+
+	t.Run("output the game id total for a custom bag", func(t *testing.T) {
+		const filename = "testdata/test_input.txt"
+		const expectedTotal = 1 + 2 + 3 + 4 + 5
+
+		os.Args = []string{"cmd", "--bag", "red=20,green=13,blue=15", filename}
+
+		actualOut, actualCode, err := captureStdOut(main)
+		if err != nil {
+			assert.Fail(t, "Error: %s\n", err)
+		}
+
+		expectedOut := fmt.Sprintf("The sum of all possible game ids is %d\n", expectedTotal)
+		expectedCode := 0
+
+		assert.Equal(
+			t,
+			expectedCode,
+			actualCode,
+			"Did not exit with the expected code",
+		)
+
+		assert.Contains(
+			t,
+			actualOut,
+			expectedOut,
+			"Did not output the total correctly",
+		)
+	})
+
+	t.Run("output the colors seen", func(t *testing.T) {
+		const filename = "testdata/test_input.txt"
+
+		os.Args = []string{"cmd", "--show-colors", filename}
+
+		actualOut, actualCode, err := captureStdOut(main)
+		if err != nil {
+			assert.Fail(t, "Error: %s\n", err)
+		}
+
+		expectedOut := "The colors seen are blue, green, red\n"
+		expectedCode := 0
+
+		assert.Equal(
+			t,
+			expectedCode,
+			actualCode,
+			"Did not exit with the expected code",
+		)
+
+		assert.Contains(
+			t,
+			actualOut,
+			expectedOut,
+			"Did not output the colors seen",
+		)
+	})
+
+	t.Run("not output the colors seen by default", func(t *testing.T) {
+		const filename = "testdata/test_input.txt"
+
+		os.Args = []string{"cmd", filename}
+
+		actualOut, actualCode, _ := captureStdOut(main)
+		expectedCode := 0
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+		assert.NotContains(t, actualOut, "The colors seen are", "Did output the colors seen by default")
+	})
+
+	t.Run("fail for unexpected colors when strict", func(t *testing.T) {
+		os.Args = []string{"cmd", "--strict-colors", "--colors", "red,green", "--bag", "red=12,green=13", "testdata/test_input.txt"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 2
+
+		assert.Equal(
+			t,
+			expectedCode,
+			actualCode,
+			"Did not exit with the expected code",
+		)
+	})
+
+	t.Run("fail when the bag contains unknown colors", func(t *testing.T) {
+		os.Args = []string{"cmd", "--bag", "red=12,purple=3", "testdata/test_input.txt"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 1
+
+		assert.Equal(
+			t,
+			expectedCode,
+			actualCode,
+			"Did not exit with the expected code",
+		)
+	})
+
+	t.Run("output the inferred bags", func(t *testing.T) {
+		const filename = "testdata/test_input.txt"
+
+		os.Args = []string{"cmd", "--infer", "--spread", "5", filename}
+
+		actualOut, actualCode, err := captureStdOut(main)
+		if err != nil {
+			assert.Fail(t, "Error: %s\n", err)
+		}
+
+		expectedCode := 0
+
+		assert.Equal(
+			t,
+			expectedCode,
+			actualCode,
+			"Did not exit with the expected code",
+		)
+
+		assert.Contains(
+			t,
+			actualOut,
+			"The most likely bag for game 5 is blue=2 (2-7+), green=3 (3-8+), red=6 (6-11+)\n",
+			"Did not output the inferred bags",
+		)
+
+		assert.Contains(
+			t,
+			actualOut,
+			"The most likely bag for game 1 is blue>=11 (6-11+), green=5 (2-7+), red=7 (4-9+)\n",
+			"Did not mark the estimates reaching the search limit",
+		)
+	})
+
+	t.Run("fail when the search spread is negative", func(t *testing.T) {
+		os.Args = []string{"cmd", "--infer", "--spread", "-1", "testdata/test_input.txt"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 1
+
+		assert.Equal(
+			t,
+			expectedCode,
+			actualCode,
+			"Did not exit with the expected code",
+		)
+	})
+
+	t.Run("output stats as a table", func(t *testing.T) {
+		const filename = "testdata/test_input.txt"
+
+		os.Args = []string{"cmd", "stats", filename}
+
+		actualOut, actualCode, err := captureStdOut(main)
+		if err != nil {
+			assert.Fail(t, "Error: %s\n", err)
+		}
+
+		expectedCode := 0
+
+		assert.Equal(
+			t,
+			expectedCode,
+			actualCode,
+			"Did not exit with the expected code",
+		)
+
+		assert.Contains(
+			t,
+			actualOut,
+			"red    11     1    20   5.55  1    4    6    14\n",
+			"Did not output the color stats",
+		)
+
+		assert.Contains(
+			t,
+			actualOut,
+			"3     red             -8\n",
+			"Did not output the limiting colors",
+		)
+	})
+
+	t.Run("output stats as json", func(t *testing.T) {
+		const filename = "testdata/test_input.txt"
+
+		os.Args = []string{"cmd", "stats", "--format", "json", filename}
+
+		actualOut, actualCode, err := captureStdOut(main)
+		if err != nil {
+			assert.Fail(t, "Error: %s\n", err)
+		}
+
+		var stats gameids.Stats
+		err = json.Unmarshal([]byte(actualOut), &stats)
+
+		assert.Equal(
+			t,
+			0,
+			actualCode,
+			"Did not exit with the expected code",
+		)
+
+		assert.Nil(
+			t,
+			err,
+			"Did not output valid json",
+		)
+
+		assert.Equal(
+			t,
+			map[int]int{2: 1, 3: 4},
+			stats.RoundsPerGame,
+			"Did not output the rounds distribution",
+		)
+	})
+
+	t.Run("fail for unknown stats format", func(t *testing.T) {
+		os.Args = []string{"cmd", "stats", "--format", "xml", "testdata/test_input.txt"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 1
+
+		assert.Equal(
+			t,
+			expectedCode,
+			actualCode,
+			"Did not exit with the expected code",
+		)
+	})
+
+	t.Run("output the games matching a query", func(t *testing.T) {
+		const filename = "testdata/test_input.txt"
+
+		os.Args = []string{"cmd", "query", "any(round.blue > 5) && max(red) <= 14", filename}
+
+		actualOut, actualCode, err := captureStdOut(main)
+		if err != nil {
+			assert.Fail(t, "Error: %s\n", err)
+		}
+
+		expectedOut := "The matching game ids are 1, 4\nThe sum of all matching game ids is 5\n"
+		expectedCode := 0
+
+		assert.Equal(
+			t,
+			expectedCode,
+			actualCode,
+			"Did not exit with the expected code",
+		)
+
+		assert.Equal(
+			t,
+			expectedOut,
+			actualOut,
+			"Did not output the matching games",
+		)
+	})
+
+	t.Run("fail for invalid queries", func(t *testing.T) {
+		os.Args = []string{"cmd", "query", "any(red)", "testdata/test_input.txt"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 1
+
+		assert.Equal(
+			t,
+			expectedCode,
+			actualCode,
+			"Did not exit with the expected code",
+		)
+	})
+
+	t.Run("fail for queries without a file", func(t *testing.T) {
+		os.Args = []string{"cmd", "query", "red > 1"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 1
+
+		assert.Equal(
+			t,
+			expectedCode,
+			actualCode,
+			"Did not exit with the expected code",
+		)
+	})
+
 	t.Run("fail when wrong arguments are passed", func(t *testing.T) {
 		os.Args = []string{"cmd"}
 
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"adventOfCode/common/fileops"
+	"adventOfCode/common/validation"
+	"adventOfCode/day2/query"
+	"fmt"
+	"log"
+	"strings"
+)
+
+func runQuery(args []string) {
+	source, err := validation.ExtractSingleArgIgnoringOthers(args, 1)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	path, err := validation.ExtractSingleArgIgnoringOthers(args, 2)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	q, err := query.Compile(source)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	ids, idTotal, err := query.CalculateMatches(path, &fileops.FileReader{}, q)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(2)
+		return
+	}
+
+	matches := make([]string, len(ids))
+	for i, id := range ids {
+		matches[i] = fmt.Sprint(id)
+	}
+
+	fmt.Printf("The matching game ids are %s\n", strings.Join(matches, ", "))
+	fmt.Printf("The sum of all matching game ids is %d\n", idTotal)
+}
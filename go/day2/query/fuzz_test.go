@@ -0,0 +1,38 @@
+package query
+
+import (
+	"adventOfCode/day2/gameids"
+	"os"
+	"slices"
+	"testing"
+)
+
+func FuzzCompile(f *testing.F) {
+	f.Add("red > 12 || count(round.blue > 3) >= 2")
+	f.Add("any(round.green == 0) && !(id < 3)")
+	f.Add("max(round.red) + min(round.blue) * 2 <= sum(round.green) - rounds")
+
+	file, err := os.Open("../testdata/test_input.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	games, err := gameids.ParseGames(file)
+	_ = file.Close()
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Fuzz(func(t *testing.T, source string) {
+		q, err := Compile(source)
+		if err != nil {
+			return
+		}
+
+		ids := q.Filter(games)
+		for _, id := range ids {
+			if !slices.ContainsFunc(games, func(game gameids.Game) bool { return game.ID == id }) {
+				t.Errorf("query %q matched unknown game id %d", source, id)
+			}
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOperator
+	tokenDot
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	column int
+}
+
+type QueryError struct {
+	Column int
+	Msg    string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Msg)
+}
+
+var operators = []string{"&&", "||", "<=", ">=", "==", "!=", "<", ">", "!", "+", "-", "*"}
+
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+
+	for pos := 0; pos < len(source); {
+		r, size := utf8.DecodeRuneInString(source[pos:])
+		start := pos
+
+		switch {
+		case unicode.IsSpace(r):
+			pos += size
+			continue
+		case r == '.':
+			tokens = append(tokens, token{tokenDot, ".", start + 1})
+			pos += size
+			continue
+		case r == '(':
+			tokens = append(tokens, token{tokenLeftParen, "(", start + 1})
+			pos += size
+			continue
+		case r == ')':
+			tokens = append(tokens, token{tokenRightParen, ")", start + 1})
+			pos += size
+			continue
+		case unicode.IsDigit(r):
+			pos = consume(source, pos, unicode.IsDigit)
+			tokens = append(tokens, token{tokenNumber, source[start:pos], start + 1})
+			continue
+		case unicode.IsLetter(r):
+			pos = consume(source, pos, func(r rune) bool {
+				return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+			})
+			tokens = append(tokens, token{tokenIdent, source[start:pos], start + 1})
+			continue
+		}
+
+		operator := matchOperator(source[pos:])
+		if operator == "" {
+			return nil, &QueryError{start + 1, fmt.Sprintf("unexpected character [%c]", r)}
+		}
+
+		tokens = append(tokens, token{tokenOperator, operator, start + 1})
+		pos += len(operator)
+	}
+
+	return append(tokens, token{tokenEOF, "", len(source) + 1}), nil
+}
+
+func matchOperator(input string) string {
+	for _, operator := range operators {
+		if strings.HasPrefix(input, operator) {
+			return operator
+		}
+	}
+
+	return ""
+}
+
+func consume(source string, pos int, accept func(rune) bool) int {
+	for pos < len(source) {
+		r, size := utf8.DecodeRuneInString(source[pos:])
+		if !accept(r) {
+			break
+		}
+		pos += size
+	}
+
+	return pos
+}
@@ -0,0 +1,52 @@
+package query
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTokenizerShould(t *testing.T) {
+
+	t.Run("tokenize query", func(t *testing.T) {
+		actual, err := tokenize("any(round.blue>10) && !(red <= 5)")
+		expected := []token{
+			{tokenIdent, "any", 1},
+			{tokenLeftParen, "(", 4},
+			{tokenIdent, "round", 5},
+			{tokenDot, ".", 10},
+			{tokenIdent, "blue", 11},
+			{tokenOperator, ">", 15},
+			{tokenNumber, "10", 16},
+			{tokenRightParen, ")", 18},
+			{tokenOperator, "&&", 20},
+			{tokenOperator, "!", 23},
+			{tokenLeftParen, "(", 24},
+			{tokenIdent, "red", 25},
+			{tokenOperator, "<=", 29},
+			{tokenNumber, "5", 32},
+			{tokenRightParen, ")", 33},
+			{tokenEOF, "", 34},
+		}
+
+		assert.Nil(t, err, "Did not tokenize query")
+		assert.Equal(t, expected, actual, "Did not tokenize query correctly")
+	})
+
+	t.Run("fail for unexpected characters", func(t *testing.T) {
+		_, err := tokenize("red & 5")
+		expected := "column 5: unexpected character [&]"
+
+		assert.EqualError(t, err, expected, "Did not fail for unexpected character")
+	})
+
+}
+
+func BenchmarkTokenizer(b *testing.B) {
+
+	b.Run("tokenization", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = tokenize("any(round.blue > 10) && max(red) <= 5")
+		}
+	})
+
+}
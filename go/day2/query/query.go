@@ -0,0 +1,519 @@
+package query
+
+import (
+	"adventOfCode/common/fileops"
+	"adventOfCode/day2/gameids"
+	"fmt"
+	"strconv"
+)
+
+type valueType int
+
+const (
+	typeNumber valueType = iota
+	typeBool
+)
+
+func (t valueType) String() string {
+	if t == typeBool {
+		return "boolean"
+	}
+
+	return "number"
+}
+
+type value struct {
+	number  int
+	boolean bool
+}
+
+type scope struct {
+	game  gameids.Game
+	round gameids.Round
+}
+
+type node interface {
+	eval(s scope) value
+}
+
+type numberLiteral struct {
+	number int
+}
+
+func (n numberLiteral) eval(scope) value {
+	return value{number: n.number}
+}
+
+type gameField struct {
+	name string
+}
+
+func (n gameField) eval(s scope) value {
+	if n.name == "id" {
+		return value{number: s.game.ID}
+	}
+
+	return value{number: len(s.game.Rounds)}
+}
+
+type colorReference struct {
+	color string
+}
+
+func (n colorReference) eval(s scope) value {
+	if s.round != nil {
+		return value{number: s.round[n.color]}
+	}
+
+	return value{number: s.game.MinimumSet(nil)[n.color]}
+}
+
+type aggregate struct {
+	function string
+	argument node
+}
+
+func (n aggregate) eval(s scope) value {
+	var result value
+
+	switch n.function {
+	case "all":
+		result.boolean = true
+	case "min", "max":
+		if len(s.game.Rounds) == 0 {
+			return result
+		}
+		result = n.argument.eval(scope{s.game, s.game.Rounds[0]})
+	}
+
+	for _, round := range s.game.Rounds {
+		current := n.argument.eval(scope{s.game, round})
+
+		switch n.function {
+		case "any":
+			result.boolean = result.boolean || current.boolean
+		case "all":
+			result.boolean = result.boolean && current.boolean
+		case "count":
+			if current.boolean {
+				result.number++
+			}
+		case "sum":
+			result.number += current.number
+		case "min":
+			result.number = min(result.number, current.number)
+		case "max":
+			result.number = max(result.number, current.number)
+		}
+	}
+
+	return result
+}
+
+type unary struct {
+	operator string
+	operand  node
+}
+
+func (n unary) eval(s scope) value {
+	operand := n.operand.eval(s)
+
+	if n.operator == "!" {
+		return value{boolean: !operand.boolean}
+	}
+
+	return value{number: -operand.number}
+}
+
+type binary struct {
+	operator string
+	left     node
+	right    node
+}
+
+func (n binary) eval(s scope) value {
+	left := n.left.eval(s)
+
+	switch n.operator {
+	case "&&":
+		return value{boolean: left.boolean && n.right.eval(s).boolean}
+	case "||":
+		return value{boolean: left.boolean || n.right.eval(s).boolean}
+	}
+
+	right := n.right.eval(s)
+
+	switch n.operator {
+	case "+":
+		return value{number: left.number + right.number}
+	case "-":
+		return value{number: left.number - right.number}
+	case "*":
+		return value{number: left.number * right.number}
+	case "<":
+		return value{boolean: left.number < right.number}
+	case "<=":
+		return value{boolean: left.number <= right.number}
+	case ">":
+		return value{boolean: left.number > right.number}
+	case ">=":
+		return value{boolean: left.number >= right.number}
+	case "==":
+		return value{boolean: left.number == right.number}
+	}
+
+	return value{boolean: left.number != right.number}
+}
+
+type Query struct {
+	source string
+	root   node
+}
+
+func Compile(source string) (*Query, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	root, kind, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.current().kind != tokenEOF {
+		return nil, p.fail(fmt.Sprintf("unexpected [%s]", p.current().text))
+	}
+
+	if kind != typeBool {
+		return nil, &QueryError{1, "query must be a boolean expression"}
+	}
+
+	return &Query{source, root}, nil
+}
+
+func (q *Query) String() string {
+	return q.source
+}
+
+func (q *Query) Match(game gameids.Game) bool {
+	return q.root.eval(scope{game: game}).boolean
+}
+
+func (q *Query) Filter(games []gameids.Game) []int {
+	var ids []int
+
+	for _, game := range games {
+		if q.Match(game) {
+			ids = append(ids, game.ID)
+		}
+	}
+
+	return ids
+}
+
+func CalculateMatches(path string, reader fileops.ReadableFile, q *Query) (ids []int, idTotal int, err error) {
+	file, err := fileops.OpenFile(path, reader)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	defer func() {
+		_ = fileops.CloseFile(file)
+	}()
+
+	games, err := gameids.ParseGames(file)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	ids = q.Filter(games)
+	for _, id := range ids {
+		idTotal += id
+	}
+
+	return ids, idTotal, nil
+}
+
+type parser struct {
+	tokens        []token
+	pos           int
+	inAggregation bool
+}
+
+func (p *parser) current() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+func (p *parser) isOperator(operators ...string) bool {
+	if p.current().kind != tokenOperator {
+		return false
+	}
+
+	for _, operator := range operators {
+		if p.current().text == operator {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *parser) or() (node, valueType, error) {
+	return p.logical("||", p.and)
+}
+
+func (p *parser) and() (node, valueType, error) {
+	return p.logical("&&", p.comparison)
+}
+
+func (p *parser) logical(operator string, operand func() (node, valueType, error)) (node, valueType, error) {
+	left, leftType, err := operand()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for p.isOperator(operator) {
+		tok := p.advance()
+
+		right, rightType, err := operand()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if leftType != typeBool || rightType != typeBool {
+			return nil, 0, mismatch(tok, typeBool)
+		}
+
+		left = binary{operator, left, right}
+	}
+
+	return left, leftType, nil
+}
+
+func (p *parser) comparison() (node, valueType, error) {
+	left, leftType, err := p.additive()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !p.isOperator("<", "<=", ">", ">=", "==", "!=") {
+		return left, leftType, nil
+	}
+
+	tok := p.advance()
+
+	right, rightType, err := p.additive()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if leftType != typeNumber || rightType != typeNumber {
+		return nil, 0, mismatch(tok, typeNumber)
+	}
+
+	return binary{tok.text, left, right}, typeBool, nil
+}
+
+func (p *parser) additive() (node, valueType, error) {
+	left, leftType, err := p.multiplicative()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for p.isOperator("+", "-") {
+		tok := p.advance()
+
+		right, rightType, err := p.multiplicative()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if leftType != typeNumber || rightType != typeNumber {
+			return nil, 0, mismatch(tok, typeNumber)
+		}
+
+		left = binary{tok.text, left, right}
+	}
+
+	return left, leftType, nil
+}
+
+func (p *parser) multiplicative() (node, valueType, error) {
+	left, leftType, err := p.unary()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for p.isOperator("*") {
+		tok := p.advance()
+
+		right, rightType, err := p.unary()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if leftType != typeNumber || rightType != typeNumber {
+			return nil, 0, mismatch(tok, typeNumber)
+		}
+
+		left = binary{tok.text, left, right}
+	}
+
+	return left, leftType, nil
+}
+
+func (p *parser) unary() (node, valueType, error) {
+	if !p.isOperator("!", "-") {
+		return p.primary()
+	}
+
+	tok := p.advance()
+
+	operand, operandType, err := p.unary()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	expected := typeNumber
+	if tok.text == "!" {
+		expected = typeBool
+	}
+
+	if operandType != expected {
+		return nil, 0, mismatch(tok, expected)
+	}
+
+	return unary{tok.text, operand}, expected, nil
+}
+
+func (p *parser) primary() (node, valueType, error) {
+	tok := p.current()
+
+	switch tok.kind {
+	case tokenNumber:
+		p.advance()
+
+		number, err := strconv.Atoi(tok.text)
+		if err != nil {
+			return nil, 0, p.failAt(tok, fmt.Sprintf("invalid number [%s]", tok.text))
+		}
+
+		return numberLiteral{number}, typeNumber, nil
+	case tokenLeftParen:
+		p.advance()
+
+		inner, innerType, err := p.or()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if p.current().kind != tokenRightParen {
+			return nil, 0, p.fail("expected \")\"")
+		}
+		p.advance()
+
+		return inner, innerType, nil
+	case tokenIdent:
+		return p.identifier()
+	}
+
+	if tok.kind == tokenEOF {
+		return nil, 0, p.fail("unexpected end of query")
+	}
+
+	return nil, 0, p.fail(fmt.Sprintf("unexpected [%s]", tok.text))
+}
+
+func (p *parser) identifier() (node, valueType, error) {
+	tok := p.advance()
+
+	switch tok.text {
+	case "id", "rounds":
+		return gameField{tok.text}, typeNumber, nil
+	case "round":
+		if !p.inAggregation {
+			return nil, 0, p.failAt(tok, "round used outside of an aggregate")
+		}
+
+		if p.current().kind != tokenDot {
+			return nil, 0, p.fail("expected \".\" after round")
+		}
+		p.advance()
+
+		if p.current().kind != tokenIdent {
+			return nil, 0, p.fail("expected color after \"round.\"")
+		}
+
+		return colorReference{p.advance().text}, typeNumber, nil
+	}
+
+	if p.current().kind != tokenLeftParen {
+		return colorReference{tok.text}, typeNumber, nil
+	}
+
+	return p.aggregate(tok)
+}
+
+func (p *parser) aggregate(function token) (node, valueType, error) {
+	var argumentType, resultType valueType
+
+	switch function.text {
+	case "any", "all":
+		argumentType, resultType = typeBool, typeBool
+	case "count":
+		argumentType, resultType = typeBool, typeNumber
+	case "min", "max", "sum":
+		argumentType, resultType = typeNumber, typeNumber
+	default:
+		return nil, 0, p.failAt(function, fmt.Sprintf("unknown function [%s]", function.text))
+	}
+
+	if p.inAggregation {
+		return nil, 0, p.failAt(function, fmt.Sprintf("nested aggregate [%s]", function.text))
+	}
+
+	p.advance()
+	p.inAggregation = true
+
+	argument, kind, err := p.or()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	p.inAggregation = false
+
+	if p.current().kind != tokenRightParen {
+		return nil, 0, p.fail("expected \")\"")
+	}
+	p.advance()
+
+	if kind != argumentType {
+		return nil, 0, p.failAt(function, fmt.Sprintf("%s expects a %s argument", function.text, argumentType))
+	}
+
+	return aggregate{function.text, argument}, resultType, nil
+}
+
+func (p *parser) fail(msg string) error {
+	return p.failAt(p.current(), msg)
+}
+
+func (p *parser) failAt(tok token, msg string) error {
+	return &QueryError{tok.column, msg}
+}
+
+func mismatch(operator token, expected valueType) error {
+	return &QueryError{operator.column, fmt.Sprintf("operator [%s] expects %s operands", operator.text, expected)}
+}
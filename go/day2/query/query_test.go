@@ -0,0 +1,155 @@
+package query
+
+import (
+	"adventOfCode/day2/gameids"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"strings"
+	"testing"
+)
+
+type MockFileReader struct {
+	mock.Mock
+}
+
+func (mockReader *MockFileReader) Open(path string) (io.ReadCloser, error) {
+	args := mockReader.Called(path)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+var games = []gameids.Game{
+	{ID: 1, Rounds: []gameids.Round{{"blue": 3, "red": 4}, {"red": 1, "green": 2, "blue": 6}, {"green": 2}}},
+	{ID: 2, Rounds: []gameids.Round{{"blue": 1, "green": 2}, {"green": 3, "blue": 4, "red": 1}, {"green": 1, "blue": 1}}},
+	{ID: 3, Rounds: []gameids.Round{{"green": 8, "blue": 6, "red": 20}, {"blue": 5, "red": 4, "green": 13}, {"green": 5, "red": 1}}},
+	{ID: 4, Rounds: []gameids.Round{{"green": 1, "red": 3, "blue": 6}, {"green": 3, "red": 6}, {"green": 3, "blue": 15, "red": 14}}},
+	{ID: 5, Rounds: []gameids.Round{{"red": 6, "blue": 1, "green": 3}, {"blue": 2, "red": 1, "green": 2}}},
+}
+
+func TestQueryShould(t *testing.T) {
+
+	tests := []struct {
+		source   string
+		expected []int
+	}{
+		{"any(round.blue > 10) && max(red) <= 14", []int{4}},
+		{"any(round.blue > 10) && max(red) <= 5", nil},
+		{"red <= 12 && green <= 13 && blue <= 14", []int{1, 2, 5}},
+		{"all(blue + red + green <= 10)", []int{1, 2, 5}},
+		{"count(round.red > 0) == 3", []int{3, 4}},
+		{"sum(green) >= 10 || rounds == 2", []int{3, 5}},
+		{"min(round.blue) == 0", []int{1, 3, 4}},
+		{"!(id == 1 || id == 2)", []int{3, 4, 5}},
+		{"red * green * blue == 48", []int{1}},
+		{"-red < -10", []int{3, 4}},
+		{"purple > 0", nil},
+	}
+
+	for _, test := range tests {
+		t.Run("filter games matching "+test.source, func(t *testing.T) {
+			q, err := Compile(test.source)
+
+			assert.Nil(t, err, "Did not compile query")
+			assert.Equal(t, test.expected, q.Filter(games), "Did not filter games correctly")
+		})
+	}
+
+	failures := []struct {
+		source   string
+		expected string
+	}{
+		{"", "column 1: unexpected end of query"},
+		{"red", "column 1: query must be a boolean expression"},
+		{"red > 5 && 3", "column 9: operator [&&] expects boolean operands"},
+		{"any(red) > 2", "column 1: any expects a boolean argument"},
+		{"max(red > 2) > 1", "column 1: max expects a number argument"},
+		{"round.red > 5", "column 1: round used outside of an aggregate"},
+		{"any(max(red) > 2)", "column 5: nested aggregate [max]"},
+		{"median(red) > 2", "column 1: unknown function [median]"},
+		{"any(round red > 2)", "column 11: expected \".\" after round"},
+		{"(red > 2", "column 9: expected \")\""},
+		{"red > 2 2", "column 9: unexpected [2]"},
+		{"!red", "column 1: operator [!] expects boolean operands"},
+		{"red > 99999999999999999999", "column 7: invalid number [99999999999999999999]"},
+	}
+
+	for _, test := range failures {
+		t.Run("fail to compile "+test.source, func(t *testing.T) {
+			_, err := Compile(test.source)
+
+			assert.EqualError(t, err, test.expected, "Did not fail with a precise error")
+		})
+	}
+
+	t.Run("keep the query source", func(t *testing.T) {
+		q, _ := Compile("red > 5")
+
+		assert.Equal(t, "red > 5", q.String(), "Did not keep the query source")
+	})
+
+}
+
+func TestMatchesCalculationShould(t *testing.T) {
+
+	t.Run("calculate matching game ids and their sum", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "Game 1: 3 blue, 4 red\nGame 2: 11 blue; 2 green\nGame 3: 12 blue, 1 red"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		q, _ := Compile("any(round.blue > 10)")
+		ids, total, err := CalculateMatches(fileName, mockReader, q)
+
+		assert.Nil(t, err, "Did not calculate matches")
+		assert.Equal(t, []int{2, 3}, ids, "Did not determine matching game ids")
+		assert.Equal(t, 5, total, "Did not calculate the sum of matching game ids")
+	})
+
+	t.Run("fail when unable to parse games", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "Game 1 3 blue"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		q, _ := Compile("blue > 1")
+		_, _, err := CalculateMatches(fileName, mockReader, q)
+
+		var parseErr *gameids.ParseError
+		assert.ErrorAs(t, err, &parseErr, "Did not fail with a parse error")
+	})
+
+	t.Run("fail when unable to read file", func(t *testing.T) {
+		const fileName = "test_input.txt"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader("")), errors.New("file open error"))
+
+		q, _ := Compile("blue > 1")
+		_, _, err := CalculateMatches(fileName, mockReader, q)
+		expected := "file open error"
+
+		assert.EqualError(t, err, expected, "Did not fail when unable to read file")
+	})
+
+}
+
+func BenchmarkQuery(b *testing.B) {
+
+	b.Run("compilation", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = Compile("any(round.blue > 10) && max(red) <= 5")
+		}
+	})
+
+	b.Run("filtering", func(b *testing.B) {
+		q, _ := Compile("any(round.blue > 10) && max(red) <= 5")
+
+		for i := 0; i < b.N; i++ {
+			_ = q.Filter(games)
+		}
+	})
+
+}
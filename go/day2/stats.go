@@ -0,0 +1,102 @@
+package main
+
+import (
+	"adventOfCode/common/fileops"
+	"adventOfCode/day2/gameids"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+func runStats(args []string) {
+	flags, bagSpec := newFlagSet()
+	format := flags.String("format", "table", "output format: table or json")
+
+	path, bag, colors, err := parseArgs(flags, bagSpec, args)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	if *format != "table" && *format != "json" {
+		log.Printf("Error: unknown format [%s]\n", *format)
+		osExit(1)
+		return
+	}
+
+	stats, err := gameids.CalculateStats(path, &fileops.FileReader{}, gameids.WithBag(bag), gameids.WithColors(colors...))
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(2)
+		return
+	}
+
+	if *format == "json" {
+		err = writeStatsJson(os.Stdout, stats)
+	} else {
+		err = writeStatsTable(os.Stdout, stats)
+	}
+
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(2)
+		return
+	}
+}
+
+func writeStatsJson(w io.Writer, stats gameids.Stats) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(stats)
+}
+
+func writeStatsTable(w io.Writer, stats gameids.Stats) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(table, "COLOR\tDRAWS\tMIN\tMAX\tMEAN\tP25\tP50\tP75\tP90")
+	for _, color := range stats.Colors {
+		_, _ = fmt.Fprintf(
+			table,
+			"%s\t%d\t%d\t%d\t%.2f\t%d\t%d\t%d\t%d\n",
+			color.Color, color.Draws, color.Min, color.Max, color.Mean,
+			color.Percentiles.P25, color.Percentiles.P50, color.Percentiles.P75, color.Percentiles.P90,
+		)
+	}
+
+	var rounds []int
+	for count := range stats.RoundsPerGame {
+		rounds = append(rounds, count)
+	}
+	slices.Sort(rounds)
+
+	_, _ = fmt.Fprintln(table, "\nROUNDS\tGAMES")
+	for _, count := range rounds {
+		_, _ = fmt.Fprintf(table, "%d\t%d\n", count, stats.RoundsPerGame[count])
+	}
+
+	_, _ = fmt.Fprintln(table, "\nGAME\tLIMITING COLOR\tSLACK")
+	for _, limiting := range stats.LimitingColors {
+		_, _ = fmt.Fprintf(table, "%d\t%s\t%d\n", limiting.GameID, limiting.Color, limiting.Slack)
+	}
+
+	_, _ = fmt.Fprintln(table, "\nCOLOR\tLIMIT\tPOSSIBLE FROM\tGAMES")
+	for _, sensitivity := range stats.Sensitivity {
+		for _, threshold := range sensitivity.Thresholds {
+			ids := make([]string, len(threshold.GameIDs))
+			for i, id := range threshold.GameIDs {
+				ids[i] = fmt.Sprint(id)
+			}
+
+			_, _ = fmt.Fprintf(table, "%s\t%d\t%d\t%s\n", sensitivity.Color, sensitivity.Limit, threshold.Limit, strings.Join(ids, ", "))
+		}
+	}
+
+	return table.Flush()
+}
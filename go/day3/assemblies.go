@@ -0,0 +1,73 @@
+package main
+
+import (
+	"adventOfCode/common/fileops"
+	"adventOfCode/day3/schematic"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+func runAssemblies(path string, format string, opts ...schematic.Option) {
+	if format != "table" && format != "json" {
+		log.Printf("Error: unknown format [%s]\n", format)
+		osExit(1)
+		return
+	}
+
+	assemblies, err := schematic.CalculateAssemblies(path, &fileops.FileReader{}, opts...)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(2)
+		return
+	}
+
+	if format == "json" {
+		err = writeAssembliesJson(os.Stdout, assemblies)
+	} else {
+		err = writeAssembliesTable(os.Stdout, assemblies)
+	}
+
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(2)
+		return
+	}
+}
+
+func writeAssembliesJson(w io.Writer, assemblies []schematic.Assembly) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if assemblies == nil {
+		assemblies = []schematic.Assembly{}
+	}
+
+	return encoder.Encode(assemblies)
+}
+
+func writeAssembliesTable(w io.Writer, assemblies []schematic.Assembly) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(table, "ASSEMBLY\tPARTS\tSYMBOLS\tTOTAL\tBOUNDS")
+	for i, assembly := range assemblies {
+		parts := make([]string, len(assembly.Parts))
+		for j, part := range assembly.Parts {
+			parts[j] = fmt.Sprint(part)
+		}
+
+		bounds := assembly.Bounds
+		_, _ = fmt.Fprintf(
+			table,
+			"%d\t%s\t%s\t%d\t%d,%d-%d,%d\n",
+			i+1, strings.Join(parts, ", "), strings.Join(assembly.Symbols, " "), assembly.Total,
+			bounds.Top, bounds.Left, bounds.Bottom, bounds.Right,
+		)
+	}
+
+	return table.Flush()
+}
@@ -4,9 +4,13 @@ import (
 	"adventOfCode/common/fileops"
 	"adventOfCode/common/validation"
 	"adventOfCode/day3/schematic"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"strings"
+	"unicode/utf8"
 )
 
 var osExit = os.Exit
@@ -14,14 +18,81 @@ var osExit = os.Exit
 func main() {
 	log.SetFlags(0)
 
-	path, err := validation.ExtractSingleArgIgnoringOthers(os.Args, 2)
+	flags := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	layoutName := flags.String("layout", "ragged", "row layout: ragged or rectangular")
+	gearSpec := flags.String("gear", "default", "gear rule: <symbol>:<count>[+]:<product|sum|max>")
+	lint := flags.Bool("lint", false, "report diagnostics for unusual layouts instead of totals")
+	assemblies := flags.Bool("assemblies", false, "report connected assemblies instead of totals")
+	format := flags.String("format", "table", "assemblies output format: table or json")
+	stream := flags.Bool("stream", false, "stream the schematic three rows at a time")
+	renderName := flags.String("render", "", "render the schematic instead of totals: ansi, html or svg")
+	symbolSpec := flags.String("symbols", "default", "symbol policy: default, any, set:<chars> or category:<unicode categories>")
+	showSymbols := flags.Bool("show-symbols", false, "report the symbols found and the characters ignored as symbols")
+
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	layout, err := schematic.ParseLayout(*layoutName)
 	if err != nil {
 		log.Printf("Error: %s\n", err)
 		osExit(1)
 		return
 	}
 
-	schematicValuesTotal, gearRatiosTotal, err := schematic.CalculateTotals(path, &fileops.FileReader{})
+	symbols, err := schematic.ParseSymbolPolicy(*symbolSpec)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	gearRule, err := schematic.ParseGearRule(*gearSpec)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	path, err := validation.ExtractSingleArgIgnoringOthers(flags.Args(), 1)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	if *renderName != "" {
+		render(path, *renderName, schematic.WithLayout(layout), schematic.WithSymbols(symbols), schematic.WithGearRule(gearRule))
+		return
+	}
+
+	if *lint {
+		runLint(path, schematic.WithLayout(layout), schematic.WithSymbols(symbols), schematic.WithGearRule(gearRule))
+		return
+	}
+
+	if *assemblies {
+		runAssemblies(path, *format, schematic.WithLayout(layout), schematic.WithSymbols(symbols), schematic.WithGearRule(gearRule))
+		return
+	}
+
+	var symbolsFound []rune
+	opts := []schematic.Option{
+		schematic.WithLayout(layout),
+		schematic.WithSymbols(symbols),
+		schematic.WithGearRule(gearRule),
+		schematic.WithSymbolsFoundHandler(func(found []rune) {
+			symbolsFound = found
+		}),
+	}
+	if *stream {
+		opts = append(opts, schematic.WithStreaming())
+	}
+
+	schematicValuesTotal, gearRatiosTotal, err := schematic.CalculateTotals(path, &fileops.FileReader{}, opts...)
 	if err != nil {
 		log.Printf("Error: %s\n", err)
 		osExit(2)
@@ -30,4 +101,54 @@ func main() {
 
 	fmt.Printf("The sum of all schematic values is %d\n", schematicValuesTotal)
 	fmt.Printf("The sum of all gear ratios is %d\n", gearRatiosTotal)
+
+	if *showSymbols {
+		printSymbols(symbolsFound, symbols)
+	}
+}
+
+func printSymbols(symbolsFound []rune, symbols schematic.SymbolPolicy) {
+	var recognised, ignored []string
+	for _, symbol := range symbolsFound {
+		if symbol < utf8.RuneSelf && symbols.IsSymbol(byte(symbol)) {
+			recognised = append(recognised, string(symbol))
+		} else {
+			ignored = append(ignored, string(symbol))
+		}
+	}
+
+	fmt.Printf("The symbols found are %s\n", strings.Join(recognised, " "))
+	if len(ignored) > 0 {
+		fmt.Printf("The characters ignored as symbols are %s\n", strings.Join(ignored, " "))
+	}
+}
+
+func render(path string, formatName string, opts ...schematic.Option) {
+	format, err := schematic.ParseRenderFormat(formatName)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	if err := schematic.Render(path, &fileops.FileReader{}, format, os.Stdout, opts...); err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(2)
+		return
+	}
+}
+
+func runLint(path string, opts ...schematic.Option) {
+	diagnostics, err := schematic.Lint(path, &fileops.FileReader{}, opts...)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(2)
+		return
+	}
+
+	for _, diagnostic := range diagnostics {
+		fmt.Println(diagnostic)
+	}
+
+	fmt.Printf("Found %d warnings\n", len(diagnostics))
 }
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"adventOfCode/day3/schematic"
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"io"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -42,6 +45,235 @@ func TestApplicationShould(t *testing.T) {
 		assert.Contains(t, actualOut, expectedOut, "Did not output the gear ratios total correctly")
 	})
 
+	t.Run("output the totals for ragged rows", func(t *testing.T) {
+		const filename = "testdata/ragged_input.txt"
+		const expectedTotal = 467*35 + 755*598
+
+		os.Args = []string{"cmd", filename}
+
+		actualOut, actualCode, _ := captureStdOut(main)
+
+		expectedOut := fmt.Sprintf("The sum of all gear ratios is %d\n", expectedTotal)
+		expectedCode := 0
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+		assert.Contains(t, actualOut, expectedOut, "Did not output the gear ratios total correctly")
+	})
+
+	t.Run("output the totals when streaming", func(t *testing.T) {
+		const filename = "testdata/test_input.txt"
+		const expectedTotal = 467 + 35 + 633 + 617 + 592 + 755 + 664 + 598
+
+		os.Args = []string{"cmd", "--stream", filename}
+
+		actualOut, actualCode, _ := captureStdOut(main)
+
+		expectedOut := fmt.Sprintf("The sum of all schematic values is %d\nThe sum of all gear ratios is %d\n", expectedTotal, 467*35+755*598)
+		expectedCode := 0
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+		assert.Contains(t, actualOut, expectedOut, "Did not output the streamed totals correctly")
+	})
+
+	t.Run("fail for ragged rows when rectangular rows are required", func(t *testing.T) {
+		os.Args = []string{"cmd", "--layout", "rectangular", "testdata/ragged_input.txt"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 2
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+	})
+
+	t.Run("fail for unknown layouts", func(t *testing.T) {
+		os.Args = []string{"cmd", "--layout", "hexagonal", "testdata/test_input.txt"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 1
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+	})
+
+	t.Run("output the symbols found", func(t *testing.T) {
+		const filename = "testdata/test_input.txt"
+
+		os.Args = []string{"cmd", "--show-symbols", filename}
+
+		actualOut, actualCode, _ := captureStdOut(main)
+
+		expectedOut := "The symbols found are # $ * +\n"
+		expectedCode := 0
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+		assert.Contains(t, actualOut, expectedOut, "Did not output the symbols found")
+	})
+
+	t.Run("not output the symbols by default", func(t *testing.T) {
+		const filename = "testdata/test_input.txt"
+
+		os.Args = []string{"cmd", filename}
+
+		actualOut, actualCode, _ := captureStdOut(main)
+
+		expectedCode := 0
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+		assert.NotContains(t, actualOut, "The symbols found are", "Did not hide the symbols found")
+	})
+
+	t.Run("output the totals for a custom symbol set", func(t *testing.T) {
+		const filename = "testdata/test_input.txt"
+		const expectedTotal = 467 + 35 + 617 + 592 + 755 + 664 + 598
+
+		os.Args = []string{"cmd", "--symbols", "set:*+$/", "--show-symbols", filename}
+
+		actualOut, actualCode, _ := captureStdOut(main)
+
+		expectedOut := fmt.Sprintf("The sum of all schematic values is %d\n", expectedTotal)
+		expectedCode := 0
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+		assert.Contains(t, actualOut, expectedOut, "Did not output the schematic values total correctly")
+		assert.Contains(t, actualOut, "The characters ignored as symbols are #\n", "Did not output the ignored characters")
+	})
+
+	t.Run("output the gear total for a custom gear rule", func(t *testing.T) {
+		const filename = "testdata/test_input.txt"
+
+		os.Args = []string{"cmd", "--gear", "*:1+:sum", filename}
+
+		actualOut, actualCode, _ := captureStdOut(main)
+
+		expectedOut := fmt.Sprintf("The sum of all gear ratios is %d\n", 467+35+617+755+598)
+		expectedCode := 0
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+		assert.Contains(t, actualOut, expectedOut, "Did not output the gear total correctly")
+	})
+
+	t.Run("fail for invalid gear rules", func(t *testing.T) {
+		os.Args = []string{"cmd", "--gear", "*:2:mean", "testdata/test_input.txt"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 1
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+	})
+
+	t.Run("fail for unknown symbol policies", func(t *testing.T) {
+		os.Args = []string{"cmd", "--symbols", "emoji", "testdata/test_input.txt"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 1
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+	})
+
+	t.Run("output non-ASCII characters ignored as symbols", func(t *testing.T) {
+		const filename = "testdata/unicode_input.txt"
+
+		os.Args = []string{"cmd", "--show-symbols", filename}
+
+		actualOut, actualCode, _ := captureStdOut(main)
+
+		expectedCode := 0
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+		assert.Contains(t, actualOut, "The characters ignored as symbols are ×\n", "Did not output the ignored characters")
+	})
+
+	t.Run("fail for non-ASCII symbols with unicode categories", func(t *testing.T) {
+		os.Args = []string{"cmd", "--symbols", "category:Sm", "testdata/unicode_input.txt"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 2
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+	})
+
+	t.Run("render the schematic", func(t *testing.T) {
+		const filename = "testdata/test_input.txt"
+
+		os.Args = []string{"cmd", "--render", "ansi", filename}
+
+		actualOut, actualCode, _ := captureStdOut(main)
+
+		expectedOut := "\033[32m467\033[0m..\033[31m114\033[0m..\n...\033[1;33m*\033[0m......\n"
+		expectedCode := 0
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+		assert.True(t, strings.HasPrefix(actualOut, expectedOut), "Did not render the schematic")
+		assert.NotContains(t, actualOut, "The sum of all", "Did not skip the totals when rendering")
+	})
+
+	t.Run("fail for unknown render formats", func(t *testing.T) {
+		os.Args = []string{"cmd", "--render", "pdf", "testdata/test_input.txt"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 1
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+	})
+
+	t.Run("output the assemblies as a table", func(t *testing.T) {
+		const filename = "testdata/test_input.txt"
+
+		os.Args = []string{"cmd", "--assemblies", filename}
+
+		actualOut, actualCode, _ := captureStdOut(main)
+
+		expectedCode := 0
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+		assert.Contains(t, actualOut, "ASSEMBLY  PARTS     SYMBOLS  TOTAL  BOUNDS\n", "Did not output the table header")
+		assert.Contains(t, actualOut, "1         467, 35   *        502    1,1-3,4\n", "Did not output the first assembly")
+		assert.Contains(t, actualOut, "5         755, 598  *        1353   8,6-10,9\n", "Did not output the fifth assembly")
+	})
+
+	t.Run("output the assemblies as json", func(t *testing.T) {
+		const filename = "testdata/test_input.txt"
+
+		os.Args = []string{"cmd", "--assemblies", "--format", "json", filename}
+
+		actualOut, actualCode, _ := captureStdOut(main)
+
+		var actual []schematic.Assembly
+		err := json.Unmarshal([]byte(actualOut), &actual)
+		expectedCode := 0
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+		assert.Nil(t, err, "Did not output valid json")
+		assert.Len(t, actual, 6, "Did not output all assemblies")
+		assert.Equal(t, schematic.Bounds{Top: 9, Left: 2, Bottom: 10, Right: 4}, actual[5].Bounds, "Did not output the bounds")
+	})
+
+	t.Run("fail for unknown assemblies formats", func(t *testing.T) {
+		os.Args = []string{"cmd", "--assemblies", "--format", "xml", "testdata/test_input.txt"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 1
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+	})
+
+	t.Run("output lint warnings", func(t *testing.T) {
+		const filename = "testdata/lint_input.txt"
+
+		os.Args = []string{"cmd", "--lint", filename}
+
+		actualOut, actualCode, _ := captureStdOut(main)
+
+		expectedOut := "row 1, col 1: number 467 touches 2 symbols * # [multiple-symbols]\n" +
+			"row 1, col 10: number 12 ends the row and may continue as 34 on row 2 [wrapped-number]\n" +
+			"row 3, col 5: number 007 has leading zeros [leading-zeros]\n" +
+			"row 3, col 10: symbol $ touches no numbers [isolated-symbol]\n" +
+			"row 4, col 3: non-ASCII bytes [e2 80 a6] [non-ascii]\n" +
+			"Found 5 warnings\n"
+		expectedCode := 0
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+		assert.Equal(t, expectedOut, actualOut, "Did not output the lint warnings")
+	})
+
 	t.Run("fail when wrong arguments are passed", func(t *testing.T) {
 		os.Args = []string{"cmd"}
 
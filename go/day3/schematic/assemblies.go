@@ -0,0 +1,153 @@
+package schematic
+
+import (
+	"adventOfCode/common/fileops"
+	"adventOfCode/common/grid"
+	"cmp"
+	"slices"
+	"strconv"
+)
+
+type Bounds struct {
+	Top    int `json:"top"`
+	Left   int `json:"left"`
+	Bottom int `json:"bottom"`
+	Right  int `json:"right"`
+}
+
+type Assembly struct {
+	Parts   []int    `json:"parts"`
+	Symbols []string `json:"symbols"`
+	Total   int      `json:"total"`
+	Bounds  Bounds   `json:"bounds"`
+}
+
+type disjointSet struct {
+	parent []int
+	rank   []int
+}
+
+func newDisjointSet(size int) *disjointSet {
+	set := &disjointSet{make([]int, size), make([]int, size)}
+	for i := range set.parent {
+		set.parent[i] = i
+	}
+
+	return set
+}
+
+func (set *disjointSet) find(node int) int {
+	for set.parent[node] != node {
+		set.parent[node] = set.parent[set.parent[node]]
+		node = set.parent[node]
+	}
+
+	return node
+}
+
+func (set *disjointSet) union(a int, b int) {
+	rootA, rootB := set.find(a), set.find(b)
+	if rootA == rootB {
+		return
+	}
+
+	if set.rank[rootA] < set.rank[rootB] {
+		rootA, rootB = rootB, rootA
+	}
+
+	set.parent[rootB] = rootA
+	if set.rank[rootA] == set.rank[rootB] {
+		set.rank[rootA]++
+	}
+}
+
+func CalculateAssemblies(path string, reader fileops.ReadableFile, opts ...Option) ([]Assembly, error) {
+	config := newSettings(opts)
+
+	schematic, err := loadSchematic(path, reader, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return findAssemblies(schematic, config), nil
+}
+
+func findAssemblies(schematic *grid.Grid[byte], config settings) []Assembly {
+	values, _, index := extractTokens(schematic, config.gearRule.Symbol)
+
+	var symbolPoints []grid.Point
+	symbolNodes := make(map[grid.Point]int)
+	for point, cell := range schematic.All() {
+		if config.symbols.IsSymbol(cell) {
+			symbolNodes[point] = len(values) + len(symbolPoints)
+			symbolPoints = append(symbolPoints, point)
+		}
+	}
+
+	set := newDisjointSet(len(values) + len(symbolPoints))
+	for _, point := range symbolPoints {
+		for _, direction := range grid.Surrounding {
+			neighbour := point.Add(direction)
+
+			if valueIdx, ok := index[neighbour]; ok {
+				set.union(symbolNodes[point], valueIdx)
+			}
+			if symbolNode, ok := symbolNodes[neighbour]; ok {
+				set.union(symbolNodes[point], symbolNode)
+			}
+		}
+	}
+
+	components := make(map[int]*Assembly)
+	var roots []int
+
+	component := func(node int, point grid.Point) *Assembly {
+		root := set.find(node)
+
+		assembly, ok := components[root]
+		if !ok {
+			assembly = &Assembly{Bounds: Bounds{point.Row + 1, point.Col + 1, point.Row + 1, point.Col + 1}}
+			components[root] = assembly
+			roots = append(roots, root)
+		}
+
+		return assembly
+	}
+
+	for valueIdx, value := range values {
+		num, _ := strconv.Atoi(value.num)
+		assembly := component(valueIdx, grid.Point{Row: value.row, Col: value.col})
+		assembly.Parts = append(assembly.Parts, num)
+		assembly.Total += num
+		assembly.Bounds.extend(grid.Point{Row: value.row, Col: value.col})
+		assembly.Bounds.extend(grid.Point{Row: value.row, Col: value.col + len(value.num) - 1})
+	}
+
+	for _, point := range symbolPoints {
+		cell, _ := schematic.At(point)
+		assembly := component(symbolNodes[point], point)
+		assembly.Symbols = append(assembly.Symbols, string(cell))
+		assembly.Bounds.extend(point)
+	}
+
+	var assemblies []Assembly
+	for _, root := range roots {
+		assembly := components[root]
+		if len(assembly.Parts) > 0 && len(assembly.Symbols) > 0 {
+			assemblies = append(assemblies, *assembly)
+		}
+	}
+
+	slices.SortStableFunc(assemblies, func(a Assembly, b Assembly) int {
+		return cmp.Or(cmp.Compare(a.Bounds.Top, b.Bounds.Top), cmp.Compare(a.Bounds.Left, b.Bounds.Left))
+	})
+
+	return assemblies
+}
+
+func (bounds *Bounds) extend(point grid.Point) {
+	bounds.Top = min(bounds.Top, point.Row+1)
+	bounds.Left = min(bounds.Left, point.Col+1)
+	bounds.Bottom = max(bounds.Bottom, point.Row+1)
+	bounds.Right = max(bounds.Right, point.Col+1)
+}
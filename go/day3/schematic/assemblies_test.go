@@ -0,0 +1,86 @@
+package schematic
+
+import (
+	"adventOfCode/common/grid"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestAssembliesShould(t *testing.T) {
+
+	t.Run("find connected assemblies", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "467..114..\n...*......\n..35..633.\n......#...\n617*......\n.....+.58.\n..592.....\n......755.\n...$.*....\n.664.598.."
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		actual, err := CalculateAssemblies(fileName, mockReader)
+		expected := []Assembly{
+			{[]int{467, 35}, []string{"*"}, 502, Bounds{1, 1, 3, 4}},
+			{[]int{633}, []string{"#"}, 633, Bounds{3, 7, 4, 9}},
+			{[]int{617}, []string{"*"}, 617, Bounds{5, 1, 5, 4}},
+			{[]int{592}, []string{"+"}, 592, Bounds{6, 3, 7, 6}},
+			{[]int{755, 598}, []string{"*"}, 1353, Bounds{8, 6, 10, 9}},
+			{[]int{664}, []string{"$"}, 664, Bounds{9, 2, 10, 4}},
+		}
+
+		assert.Nil(t, err, "Did not calculate assemblies")
+		assert.Equal(t, expected, actual, "Did not find assemblies correctly")
+	})
+
+	t.Run("join assemblies through adjacent symbols", func(t *testing.T) {
+		var schematic [][]byte
+		schematic = append(schematic, []byte("12......"))
+		schematic = append(schematic, []byte("..*#...."))
+		schematic = append(schematic, []byte("....+.7."))
+		schematic = append(schematic, []byte(".....34."))
+
+		actual := findAssemblies(grid.FromRows(schematic), newSettings(nil))
+		expected := []Assembly{
+			{[]int{12, 34}, []string{"*", "#", "+"}, 46, Bounds{1, 1, 4, 7}},
+		}
+
+		assert.Equal(t, expected, actual, "Did not join assemblies through adjacent symbols")
+	})
+
+	t.Run("ignore numbers and symbols that are not connected", func(t *testing.T) {
+		var schematic [][]byte
+		schematic = append(schematic, []byte("12..*"))
+		schematic = append(schematic, []byte("....."))
+		schematic = append(schematic, []byte("34.56"))
+
+		actual := findAssemblies(grid.FromRows(schematic), newSettings(nil))
+
+		assert.Empty(t, actual, "Did not ignore unconnected numbers and symbols")
+	})
+
+	t.Run("merge disjoint sets", func(t *testing.T) {
+		set := newDisjointSet(5)
+		set.union(0, 1)
+		set.union(3, 4)
+		set.union(1, 4)
+
+		assert.Equal(t, set.find(0), set.find(3), "Did not merge disjoint sets")
+		assert.NotEqual(t, set.find(0), set.find(2), "Did not keep disjoint sets apart")
+	})
+
+}
+
+func BenchmarkAssemblies(b *testing.B) {
+
+	b.Run("assembly search", func(b *testing.B) {
+		var schematic [][]byte
+		schematic = append(schematic, []byte("467..114.."))
+		schematic = append(schematic, []byte("...*......"))
+		schematic = append(schematic, []byte("..35..633."))
+		schematic = append(schematic, []byte("......#..."))
+
+		for i := 0; i < b.N; i++ {
+			findAssemblies(grid.FromRows(schematic), newSettings(nil))
+		}
+	})
+
+}
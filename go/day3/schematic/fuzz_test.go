@@ -0,0 +1,92 @@
+package schematic
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var longNumber = regexp.MustCompile(`\d{7,}`)
+
+func addTestdata(f *testing.F) {
+	paths, _ := filepath.Glob("../testdata/*.txt")
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err == nil {
+			f.Add(string(content))
+		}
+	}
+}
+
+func FuzzCalculateTotals(f *testing.F) {
+	addTestdata(f)
+
+	f.Fuzz(func(t *testing.T, input string) {
+		const fileName = "test_input.txt"
+
+		if longNumber.MatchString(input) {
+			t.Skip()
+		}
+
+		memoryReader := new(MockFileReader)
+		memoryReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(input)), nil)
+		streamReader := new(MockFileReader)
+		streamReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(input)), nil)
+
+		valueTotal, ratioTotal, err := CalculateTotals(fileName, memoryReader, WithSymbols(NonDigitNonDot{}))
+		if err != nil {
+			return
+		}
+
+		streamedValueTotal, streamedRatioTotal, err := CalculateTotals(fileName, streamReader, WithSymbols(NonDigitNonDot{}), WithStreaming())
+
+		if valueTotal < 0 || ratioTotal < 0 {
+			t.Errorf("totals %d and %d are negative", valueTotal, ratioTotal)
+		}
+		if err != nil || streamedValueTotal != valueTotal || streamedRatioTotal != ratioTotal {
+			t.Errorf("streamed totals %d and %d (%v) differ from %d and %d", streamedValueTotal, streamedRatioTotal, err, valueTotal, ratioTotal)
+		}
+	})
+}
+
+func FuzzAnalysis(f *testing.F) {
+	addTestdata(f)
+
+	f.Fuzz(func(t *testing.T, input string) {
+		const fileName = "test_input.txt"
+
+		if longNumber.MatchString(input) {
+			t.Skip()
+		}
+
+		valueReader := new(MockFileReader)
+		valueReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(input)), nil)
+		assemblyReader := new(MockFileReader)
+		assemblyReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(input)), nil)
+		lintReader := new(MockFileReader)
+		lintReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(input)), nil)
+		renderReader := new(MockFileReader)
+		renderReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(input)), nil)
+
+		valueTotal, _, err := CalculateTotals(fileName, valueReader)
+		if err != nil {
+			return
+		}
+
+		assemblies, _ := CalculateAssemblies(fileName, assemblyReader)
+		_, _ = Lint(fileName, lintReader)
+		_ = Render(fileName, renderReader, HTML, io.Discard)
+
+		assemblyTotal := 0
+		for _, assembly := range assemblies {
+			assemblyTotal += assembly.Total
+		}
+
+		if assemblyTotal != valueTotal {
+			t.Errorf("assemblies total %d differs from schematic values total %d", assemblyTotal, valueTotal)
+		}
+	})
+}
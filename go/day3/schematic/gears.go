@@ -0,0 +1,114 @@
+package schematic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type CountMode int
+
+const (
+	Exactly CountMode = iota
+	AtLeast
+)
+
+type Aggregation int
+
+const (
+	Product Aggregation = iota
+	Sum
+	Max
+)
+
+type GearRule struct {
+	Symbol      byte
+	Count       CountMode
+	Parts       int
+	Aggregation Aggregation
+}
+
+func DefaultGearRule() GearRule {
+	return GearRule{Symbol: '*', Count: Exactly, Parts: 2, Aggregation: Product}
+}
+
+func (rule GearRule) Matches(parts int) bool {
+	if rule.Count == AtLeast {
+		return parts >= rule.Parts
+	}
+
+	return parts == rule.Parts
+}
+
+func (rule GearRule) Aggregate(parts []int) int {
+	if !rule.Matches(len(parts)) {
+		return 0
+	}
+
+	var result int
+	switch rule.Aggregation {
+	case Product:
+		result = 1
+		for _, part := range parts {
+			result *= part
+		}
+	case Sum:
+		for _, part := range parts {
+			result += part
+		}
+	case Max:
+		for _, part := range parts {
+			result = max(result, part)
+		}
+	}
+
+	return result
+}
+
+func ParseGearRule(spec string) (GearRule, error) {
+	if spec == "default" {
+		return DefaultGearRule(), nil
+	}
+
+	fields := strings.Split(spec, ":")
+	if len(fields) != 3 || len(fields[0]) != 1 {
+		return GearRule{}, fmt.Errorf("invalid gear rule [%s], expected <symbol>:<count>:<aggregation>", spec)
+	}
+
+	if !isCandidateSymbol(fields[0][0]) {
+		return GearRule{}, fmt.Errorf("invalid gear symbol [%s], expected a character other than a digit or '.'", fields[0])
+	}
+
+	rule := GearRule{Symbol: fields[0][0], Count: Exactly}
+
+	count := fields[1]
+	if strings.HasSuffix(count, "+") {
+		rule.Count = AtLeast
+		count = strings.TrimSuffix(count, "+")
+	}
+
+	parts, err := strconv.Atoi(count)
+	if err != nil || parts < 1 {
+		return GearRule{}, fmt.Errorf("invalid gear part count [%s]", fields[1])
+	}
+	rule.Parts = parts
+
+	switch fields[2] {
+	case "product":
+		rule.Aggregation = Product
+	case "sum":
+		rule.Aggregation = Sum
+	case "max":
+		rule.Aggregation = Max
+	default:
+		return GearRule{}, fmt.Errorf("unknown gear aggregation [%s]", fields[2])
+	}
+
+	return rule, nil
+}
+
+func WithGearRule(rule GearRule) Option {
+	return func(s *settings) {
+		s.gearRule = rule
+	}
+}
@@ -0,0 +1,109 @@
+package schematic
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestGearRuleShould(t *testing.T) {
+
+	tests := []struct {
+		rule     GearRule
+		parts    []int
+		expected int
+	}{
+		{DefaultGearRule(), []int{467, 35}, 467 * 35},
+		{DefaultGearRule(), []int{617}, 0},
+		{DefaultGearRule(), []int{1, 2, 3}, 0},
+		{GearRule{'*', AtLeast, 2, Product}, []int{1, 2, 3}, 6},
+		{GearRule{'*', AtLeast, 1, Sum}, []int{617}, 617},
+		{GearRule{'*', Exactly, 3, Sum}, []int{1, 2, 3}, 6},
+		{GearRule{'*', Exactly, 2, Max}, []int{755, 598}, 755},
+		{GearRule{'*', AtLeast, 3, Max}, []int{755, 598}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("aggregate %v with %v", test.parts, test.rule), func(t *testing.T) {
+			actual := test.rule.Aggregate(test.parts)
+
+			assert.Equal(t, test.expected, actual, "Did not aggregate gear parts correctly")
+		})
+	}
+
+}
+
+func TestGearRuleParsingShould(t *testing.T) {
+
+	tests := []struct {
+		spec     string
+		expected GearRule
+	}{
+		{"default", DefaultGearRule()},
+		{"*:2:product", DefaultGearRule()},
+		{"#:1+:sum", GearRule{'#', AtLeast, 1, Sum}},
+		{"@:3:max", GearRule{'@', Exactly, 3, Max}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("parse %s", test.spec), func(t *testing.T) {
+			actual, err := ParseGearRule(test.spec)
+
+			assert.Nil(t, err, "Did not parse gear rule")
+			assert.Equal(t, test.expected, actual, "Did not parse gear rule correctly")
+		})
+	}
+
+	failures := []struct {
+		spec     string
+		expected string
+	}{
+		{"*:2", "invalid gear rule [*:2], expected <symbol>:<count>:<aggregation>"},
+		{"**:2:sum", "invalid gear rule [**:2:sum], expected <symbol>:<count>:<aggregation>"},
+		{"7:2:sum", "invalid gear symbol [7], expected a character other than a digit or '.'"},
+		{".:2:sum", "invalid gear symbol [.], expected a character other than a digit or '.'"},
+		{"*:two:sum", "invalid gear part count [two]"},
+		{"*:0+:sum", "invalid gear part count [0+]"},
+		{"*:2:mean", "unknown gear aggregation [mean]"},
+	}
+
+	for _, test := range failures {
+		t.Run(fmt.Sprintf("fail to parse %s", test.spec), func(t *testing.T) {
+			_, err := ParseGearRule(test.spec)
+
+			assert.EqualError(t, err, test.expected, "Did not fail to parse gear rule")
+		})
+	}
+
+}
+
+func TestGearRulesShould(t *testing.T) {
+
+	tests := []struct {
+		rule     GearRule
+		expected int
+	}{
+		{DefaultGearRule(), 467835},
+		{GearRule{'*', AtLeast, 1, Sum}, 467 + 35 + 617 + 755 + 598},
+		{GearRule{'*', Exactly, 2, Max}, 467 + 755},
+		{GearRule{'#', Exactly, 1, Product}, 633},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("calculate gear total with %v", test.rule), func(t *testing.T) {
+			const fileName = "test_input.txt"
+			const lines = "467..114..\n...*......\n..35..633.\n......#...\n617*......\n.....+.58.\n..592.....\n......755.\n...$.*....\n.664.598.."
+
+			mockReader := new(MockFileReader)
+			mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+			_, actual, err := CalculateTotals(fileName, mockReader, WithGearRule(test.rule))
+
+			assert.Nil(t, err, "Did not calculate totals")
+			assert.Equal(t, test.expected, actual, "Did not calculate gear total correctly")
+		})
+	}
+
+}
@@ -0,0 +1,168 @@
+package schematic
+
+import (
+	"adventOfCode/common/fileops"
+	"adventOfCode/common/grid"
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+type DiagnosticKind int
+
+const (
+	MultipleSymbols DiagnosticKind = iota
+	IsolatedSymbol
+	WrappedNumber
+	LeadingZeros
+	NonASCII
+)
+
+var diagnosticNames = map[DiagnosticKind]string{
+	MultipleSymbols: "multiple-symbols",
+	IsolatedSymbol:  "isolated-symbol",
+	WrappedNumber:   "wrapped-number",
+	LeadingZeros:    "leading-zeros",
+	NonASCII:        "non-ascii",
+}
+
+func (kind DiagnosticKind) String() string {
+	return diagnosticNames[kind]
+}
+
+type Diagnostic struct {
+	Kind    DiagnosticKind
+	Row     int
+	Col     int
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("row %d, col %d: %s [%s]", d.Row, d.Col, d.Message, d.Kind)
+}
+
+func Lint(path string, reader fileops.ReadableFile, opts ...Option) ([]Diagnostic, error) {
+	config := newSettings(opts)
+
+	schematic, err := loadSchematic(path, reader, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return lintSchematic(schematic, config), nil
+}
+
+func lintSchematic(schematic *grid.Grid[byte], config settings) []Diagnostic {
+	values, _, index := extractTokens(schematic, config.gearRule.Symbol)
+
+	var diagnostics []Diagnostic
+	diagnostics = append(diagnostics, lintValues(values, schematic, config.symbols)...)
+	diagnostics = append(diagnostics, lintSymbols(schematic, index, config.symbols)...)
+	diagnostics = append(diagnostics, lintWrappedValues(values, schematic)...)
+	diagnostics = append(diagnostics, lintNonASCII(schematic)...)
+
+	slices.SortStableFunc(diagnostics, func(a Diagnostic, b Diagnostic) int {
+		return cmp.Or(cmp.Compare(a.Row, b.Row), cmp.Compare(a.Col, b.Col))
+	})
+
+	return diagnostics
+}
+
+func lintValues(values []schematicValue, schematic *grid.Grid[byte], symbols SymbolPolicy) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	for _, value := range values {
+		if len(value.num) > 1 && value.num[0] == '0' {
+			diagnostics = append(diagnostics, Diagnostic{LeadingZeros, value.row + 1, value.col + 1, fmt.Sprintf("number %s has leading zeros", value.num)})
+		}
+
+		from := grid.Point{Row: value.row - 1, Col: value.col - 1}
+		to := grid.Point{Row: value.row + 1, Col: value.col + len(value.num)}
+
+		var touching []string
+		for _, cell := range schematic.Box(from, to) {
+			if symbols.IsSymbol(cell) {
+				touching = append(touching, string(cell))
+			}
+		}
+
+		if len(touching) > 1 {
+			message := fmt.Sprintf("number %s touches %d symbols %s", value.num, len(touching), strings.Join(touching, " "))
+			diagnostics = append(diagnostics, Diagnostic{MultipleSymbols, value.row + 1, value.col + 1, message})
+		}
+	}
+
+	return diagnostics
+}
+
+func lintSymbols(schematic *grid.Grid[byte], index partIndex, symbols SymbolPolicy) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	for point, cell := range schematic.All() {
+		if !symbols.IsSymbol(cell) {
+			continue
+		}
+
+		isolated := true
+		for _, direction := range grid.Surrounding {
+			if _, ok := index[point.Add(direction)]; ok {
+				isolated = false
+				break
+			}
+		}
+
+		if isolated {
+			diagnostics = append(diagnostics, Diagnostic{IsolatedSymbol, point.Row + 1, point.Col + 1, fmt.Sprintf("symbol %c touches no numbers", cell)})
+		}
+	}
+
+	return diagnostics
+}
+
+func lintWrappedValues(values []schematicValue, schematic *grid.Grid[byte]) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	starts := make(map[int]schematicValue)
+	for _, value := range values {
+		if value.col == 0 {
+			starts[value.row] = value
+		}
+	}
+
+	for _, value := range values {
+		if value.col+len(value.num) != len(schematic.Row(value.row)) {
+			continue
+		}
+
+		if next, ok := starts[value.row+1]; ok {
+			message := fmt.Sprintf("number %s ends the row and may continue as %s on row %d", value.num, next.num, next.row+1)
+			diagnostics = append(diagnostics, Diagnostic{WrappedNumber, value.row + 1, value.col + 1, message})
+		}
+	}
+
+	return diagnostics
+}
+
+func lintNonASCII(schematic *grid.Grid[byte]) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	for rowIdx, row := range schematic.Rows() {
+		for colIdx := 0; colIdx < len(row); colIdx++ {
+			if row[colIdx] <= unicode.MaxASCII {
+				continue
+			}
+
+			start := colIdx
+			for colIdx < len(row) && row[colIdx] > unicode.MaxASCII {
+				colIdx++
+			}
+
+			message := fmt.Sprintf("non-ASCII bytes [% x]", row[start:colIdx])
+			diagnostics = append(diagnostics, Diagnostic{NonASCII, rowIdx + 1, start + 1, message})
+		}
+	}
+
+	return diagnostics
+}
@@ -0,0 +1,66 @@
+package schematic
+
+import (
+	"adventOfCode/common/grid"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLintShould(t *testing.T) {
+
+	t.Run("report unusual layouts", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "467..114.12\n34*#......\n....007..$\n..\xe2\x80\xa6.5"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		actual, err := Lint(fileName, mockReader)
+		expected := []Diagnostic{
+			{MultipleSymbols, 1, 1, "number 467 touches 2 symbols * #"},
+			{WrappedNumber, 1, 10, "number 12 ends the row and may continue as 34 on row 2"},
+			{LeadingZeros, 3, 5, "number 007 has leading zeros"},
+			{IsolatedSymbol, 3, 10, "symbol $ touches no numbers"},
+			{NonASCII, 4, 3, "non-ASCII bytes [e2 80 a6]"},
+		}
+
+		assert.Nil(t, err, "Did not lint schematic")
+		assert.Equal(t, expected, actual, "Did not report unusual layouts correctly")
+	})
+
+	t.Run("report nothing for clean schematics", func(t *testing.T) {
+		var schematic [][]byte
+		schematic = append(schematic, []byte("467..114.."))
+		schematic = append(schematic, []byte("...*......"))
+		schematic = append(schematic, []byte("..35..633."))
+		schematic = append(schematic, []byte("......#..."))
+
+		actual := lintSchematic(grid.FromRows(schematic), newSettings(nil))
+
+		assert.Empty(t, actual, "Did not report nothing for clean schematic")
+	})
+
+	t.Run("respect the symbol policy", func(t *testing.T) {
+		var schematic [][]byte
+		schematic = append(schematic, []byte("12~"))
+		schematic = append(schematic, []byte("..."))
+		schematic = append(schematic, []byte("..~"))
+
+		actual := lintSchematic(grid.FromRows(schematic), newSettings([]Option{WithSymbols(SymbolSet("~"))}))
+		expected := []Diagnostic{
+			{IsolatedSymbol, 3, 3, "symbol ~ touches no numbers"},
+		}
+
+		assert.Equal(t, expected, actual, "Did not respect the symbol policy")
+	})
+
+	t.Run("describe diagnostics", func(t *testing.T) {
+		actual := fmt.Sprint(Diagnostic{LeadingZeros, 3, 5, "number 007 has leading zeros"})
+
+		assert.Equal(t, "row 3, col 5: number 007 has leading zeros [leading-zeros]", actual, "Did not describe diagnostic")
+	})
+
+}
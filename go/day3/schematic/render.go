@@ -0,0 +1,228 @@
+package schematic
+
+import (
+	"adventOfCode/common/fileops"
+	"adventOfCode/common/grid"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type RenderFormat int
+
+const (
+	ANSI RenderFormat = iota
+	HTML
+	SVG
+)
+
+type cellClass int
+
+const (
+	plainCell cellClass = iota
+	partCell
+	looseCell
+	gearCell
+)
+
+var cssClasses = map[cellClass]string{
+	plainCell: "plain",
+	partCell:  "part",
+	looseCell: "loose",
+	gearCell:  "gear",
+}
+
+var ansiColours = map[cellClass]string{
+	partCell:  "\033[32m",
+	looseCell: "\033[31m",
+	gearCell:  "\033[1;33m",
+}
+
+const ansiReset = "\033[0m"
+
+const stylesheet = ".part { color: green; fill: green; } " +
+	".loose { color: red; fill: red; } " +
+	".gear { color: orange; fill: orange; font-weight: bold; }"
+
+const (
+	svgCellWidth  = 10
+	svgLineHeight = 18
+)
+
+type segment struct {
+	text  string
+	col   int
+	class cellClass
+	title string
+}
+
+func ParseRenderFormat(name string) (RenderFormat, error) {
+	switch name {
+	case "ansi":
+		return ANSI, nil
+	case "html":
+		return HTML, nil
+	case "svg":
+		return SVG, nil
+	}
+
+	return -1, fmt.Errorf("unknown render format [%s]", name)
+}
+
+func Render(path string, reader fileops.ReadableFile, format RenderFormat, out io.Writer, opts ...Option) error {
+	config := newSettings(opts)
+
+	schematic, err := loadSchematic(path, reader, config)
+	if err != nil {
+		return err
+	}
+
+	rows := annotateRows(schematic, config)
+
+	switch format {
+	case HTML:
+		return renderHTML(rows, out)
+	case SVG:
+		return renderSVG(rows, schematic.Width(), out)
+	}
+
+	return renderANSI(rows, out)
+}
+
+func annotateRows(schematic *grid.Grid[byte], config settings) [][]segment {
+	values, _, index := extractTokens(schematic, config.gearRule.Symbol)
+	rows := make([][]segment, schematic.Height())
+
+	for rowIdx, row := range schematic.Rows() {
+		for colIdx := 0; colIdx < len(row); colIdx++ {
+			point := grid.Point{Row: rowIdx, Col: colIdx}
+
+			if valueIdx, ok := index[point]; ok {
+				value := values[valueIdx]
+				rows[rowIdx] = append(rows[rowIdx], annotateValue(value, schematic, config))
+				colIdx += len(value.num) - 1
+				continue
+			}
+
+			if row[colIdx] == config.gearRule.Symbol {
+				parts := getGearParts(gear{rowIdx, colIdx}, values, index)
+				if config.gearRule.Matches(len(parts)) {
+					rows[rowIdx] = append(rows[rowIdx], annotateGear(point, parts, config.gearRule))
+					continue
+				}
+			}
+
+			rows[rowIdx] = appendPlain(rows[rowIdx], row[colIdx:colIdx+1], colIdx)
+		}
+	}
+
+	return rows
+}
+
+func annotateValue(value schematicValue, schematic *grid.Grid[byte], config settings) segment {
+	from := grid.Point{Row: value.row - 1, Col: value.col - 1}
+	to := grid.Point{Row: value.row + 1, Col: value.col + len(value.num)}
+
+	var adjacent []string
+	for point, cell := range schematic.Box(from, to) {
+		if config.symbols.IsSymbol(cell) {
+			adjacent = append(adjacent, fmt.Sprintf("%c at row %d, col %d", cell, point.Row+1, point.Col+1))
+		}
+	}
+
+	if len(adjacent) == 0 {
+		return segment{value.num, value.col, looseCell, fmt.Sprintf("%s: not adjacent to any symbol", value.num)}
+	}
+
+	return segment{value.num, value.col, partCell, fmt.Sprintf("%s: part number adjacent to %s", value.num, strings.Join(adjacent, ", "))}
+}
+
+func annotateGear(point grid.Point, parts []int, rule GearRule) segment {
+	nums := make([]string, len(parts))
+	for i, part := range parts {
+		nums[i] = strconv.Itoa(part)
+	}
+
+	title := fmt.Sprintf("gear at row %d, col %d with parts %s, value %d", point.Row+1, point.Col+1, strings.Join(nums, ", "), rule.Aggregate(parts))
+
+	return segment{string(rule.Symbol), point.Col, gearCell, title}
+}
+
+func appendPlain(segments []segment, cells []byte, col int) []segment {
+	last := len(segments) - 1
+	if last >= 0 && segments[last].class == plainCell {
+		segments[last].text += string(cells)
+		return segments
+	}
+
+	return append(segments, segment{string(cells), col, plainCell, ""})
+}
+
+func renderANSI(rows [][]segment, out io.Writer) error {
+	var builder strings.Builder
+
+	for _, row := range rows {
+		for _, seg := range row {
+			if seg.class == plainCell {
+				builder.WriteString(seg.text)
+				continue
+			}
+
+			builder.WriteString(ansiColours[seg.class] + seg.text + ansiReset)
+		}
+		builder.WriteString("\n")
+	}
+
+	_, err := io.WriteString(out, builder.String())
+
+	return err
+}
+
+func renderHTML(rows [][]segment, out io.Writer) error {
+	var builder strings.Builder
+
+	builder.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<style>" + stylesheet + "</style>\n</head>\n<body>\n<pre>\n")
+	for _, row := range rows {
+		for _, seg := range row {
+			if seg.class == plainCell {
+				builder.WriteString(html.EscapeString(seg.text))
+				continue
+			}
+
+			builder.WriteString(fmt.Sprintf(`<span class="%s" title="%s">%s</span>`, cssClasses[seg.class], html.EscapeString(seg.title), html.EscapeString(seg.text)))
+		}
+		builder.WriteString("\n")
+	}
+	builder.WriteString("</pre>\n</body>\n</html>\n")
+
+	_, err := io.WriteString(out, builder.String())
+
+	return err
+}
+
+func renderSVG(rows [][]segment, width int, out io.Writer) error {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="monospace" xml:space="preserve">`+"\n", width*svgCellWidth, len(rows)*svgLineHeight))
+	builder.WriteString("<style>" + stylesheet + "</style>\n")
+	for rowIdx, row := range rows {
+		for _, seg := range row {
+			x := seg.col * svgCellWidth
+			y := (rowIdx + 1) * svgLineHeight
+
+			if seg.class == plainCell {
+				builder.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="plain">%s</text>`+"\n", x, y, html.EscapeString(seg.text)))
+				continue
+			}
+
+			builder.WriteString(fmt.Sprintf(`<text x="%d" y="%d" class="%s"><title>%s</title>%s</text>`+"\n", x, y, cssClasses[seg.class], html.EscapeString(seg.title), html.EscapeString(seg.text)))
+		}
+	}
+	builder.WriteString("</svg>\n")
+
+	_, err := io.WriteString(out, builder.String())
+
+	return err
+}
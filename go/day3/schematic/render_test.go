@@ -0,0 +1,97 @@
+package schematic
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRenderShould(t *testing.T) {
+
+	const fileName = "test_input.txt"
+	const lines = "467..114..\n...*......\n..35..633.\n......#..."
+
+	t.Run("render ansi colours", func(t *testing.T) {
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		var out bytes.Buffer
+		err := Render(fileName, mockReader, ANSI, &out)
+
+		expected := "\033[32m467\033[0m..\033[31m114\033[0m..\n" +
+			"...\033[1;33m*\033[0m......\n" +
+			"..\033[32m35\033[0m..\033[32m633\033[0m.\n" +
+			"......#...\n"
+
+		assert.Nil(t, err, "Did not render schematic")
+		assert.Equal(t, expected, out.String(), "Did not render ansi colours correctly")
+	})
+
+	t.Run("render html with tooltips", func(t *testing.T) {
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		var out bytes.Buffer
+		err := Render(fileName, mockReader, HTML, &out)
+
+		assert.Nil(t, err, "Did not render schematic")
+		assert.Contains(t, out.String(), `<span class="part" title="467: part number adjacent to * at row 2, col 4">467</span>..`, "Did not render part number")
+		assert.Contains(t, out.String(), `<span class="loose" title="114: not adjacent to any symbol">114</span>`, "Did not render loose number")
+		assert.Contains(t, out.String(), `<span class="gear" title="gear at row 2, col 4 with parts 467, 35, value 16345">*</span>`, "Did not render gear")
+	})
+
+	t.Run("render svg with tooltips", func(t *testing.T) {
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		var out bytes.Buffer
+		err := Render(fileName, mockReader, SVG, &out)
+
+		assert.Nil(t, err, "Did not render schematic")
+		assert.Contains(t, out.String(), `width="100" height="72"`, "Did not size svg")
+		assert.Contains(t, out.String(), `<text x="60" y="54" class="part"><title>633: part number adjacent to # at row 4, col 7</title>633</text>`, "Did not render part number")
+		assert.Contains(t, out.String(), `<text x="0" y="72" class="plain">......#...</text>`, "Did not render plain cells")
+	})
+
+	t.Run("highlight gears according to the gear rule", func(t *testing.T) {
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		var out bytes.Buffer
+		_ = Render(fileName, mockReader, ANSI, &out, WithGearRule(GearRule{'*', Exactly, 3, Product}))
+
+		assert.Contains(t, out.String(), "\n...*......\n", "Did not leave non-matching gear plain")
+	})
+
+	t.Run("render non-ASCII characters unchanged", func(t *testing.T) {
+		const unicodeFileName = "unicode_input.txt"
+		const unicodeLines = "467..114..\n...×......\n..35..633.\n"
+
+		for _, format := range []RenderFormat{ANSI, HTML, SVG} {
+			mockReader := new(MockFileReader)
+			mockReader.On("Open", unicodeFileName).Return(io.NopCloser(strings.NewReader(unicodeLines)), nil)
+
+			var out bytes.Buffer
+			err := Render(unicodeFileName, mockReader, format, &out)
+
+			assert.Nil(t, err, "Did not render schematic")
+			assert.True(t, utf8.Valid(out.Bytes()), "Did not render valid UTF-8")
+			assert.Contains(t, out.String(), "...×......", "Did not render the non-ASCII character")
+		}
+	})
+
+	t.Run("parse render formats", func(t *testing.T) {
+		actual, err := ParseRenderFormat("svg")
+
+		assert.Nil(t, err, "Did not parse render format")
+		assert.Equal(t, SVG, actual, "Did not parse render format correctly")
+
+		_, err = ParseRenderFormat("pdf")
+
+		assert.EqualError(t, err, "unknown render format [pdf]", "Did not fail for unknown render format")
+	})
+
+}
@@ -2,10 +2,10 @@ package schematic
 
 import (
 	"adventOfCode/common/fileops"
-	"bufio"
-	"errors"
+	"adventOfCode/common/grid"
+	"fmt"
+	"slices"
 	"strconv"
-	"strings"
 	"unicode"
 )
 
@@ -20,31 +20,115 @@ type gear struct {
 	col int
 }
 
-func CalculateTotals(path string, reader fileops.ReadableFile) (schematicValueTotal int, gearRatioTotal int, errorMsg error) {
-	schematic, err := extractSchematic(path, reader)
+type partIndex map[grid.Point]int
+
+type Layout int
+
+const (
+	Ragged Layout = iota
+	Rectangular
+)
+
+type RaggedRowError struct {
+	Row      int
+	Length   int
+	Expected int
+}
+
+func (e *RaggedRowError) Error() string {
+	return fmt.Sprintf("row %d has length %d, expected %d", e.Row, e.Length, e.Expected)
+}
+
+type Option func(*settings)
+
+type settings struct {
+	layout       Layout
+	symbols      SymbolPolicy
+	symbolsFound func(found []rune)
+	gearRule     GearRule
+	streaming    bool
+}
+
+func WithLayout(layout Layout) Option {
+	return func(s *settings) {
+		s.layout = layout
+	}
+}
+
+func ParseLayout(name string) (Layout, error) {
+	switch name {
+	case "ragged":
+		return Ragged, nil
+	case "rectangular":
+		return Rectangular, nil
+	}
+
+	return -1, fmt.Errorf("unknown layout [%s]", name)
+}
+
+func CalculateTotals(path string, reader fileops.ReadableFile, opts ...Option) (schematicValueTotal int, gearRatioTotal int, errorMsg error) {
+	config := newSettings(opts)
+
+	if config.streaming {
+		return calculateStreamingTotals(path, reader, config)
+	}
+
+	schematic, err := loadSchematic(path, reader, config)
 	if err != nil {
 		return -1, -1, err
 	}
 
-	values, gears := extractTokens(schematic)
+	values, gears, index := extractTokens(schematic, config.gearRule.Symbol)
 	valueTotal := 0
 	ratioTotal := 0
 
 	for _, value := range values {
-		if isAdjacentToSymbols(value, schematic) {
+		if isAdjacentToSymbols(value, schematic, config.symbols) {
 			num, _ := strconv.Atoi(value.num)
 			valueTotal += num
 		}
 	}
 
 	for _, gear := range gears {
-		ratioTotal += getGearRatioOrZero(gear, schematic)
+		ratioTotal += getGearRatioOrZero(gear, values, index, config.gearRule)
 	}
 
 	return valueTotal, ratioTotal, nil
 }
 
-func extractSchematic(path string, reader fileops.ReadableFile) ([][]byte, error) {
+func newSettings(opts []Option) settings {
+	config := settings{layout: Ragged, symbols: DefaultSymbols(), symbolsFound: func([]rune) {}, gearRule: DefaultGearRule()}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return config
+}
+
+func loadSchematic(path string, reader fileops.ReadableFile, config settings) (*grid.Grid[byte], error) {
+	schematic, err := extractSchematic(path, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.layout == Rectangular {
+		if err := validateRectangular(schematic); err != nil {
+			return nil, err
+		}
+	}
+
+	for rowIdx, row := range schematic.Rows() {
+		if err := checkSymbolInput(config.symbols, row, rowIdx); err != nil {
+			return nil, err
+		}
+	}
+
+	config.symbolsFound(distinctSymbols(schematic))
+
+	return schematic, nil
+}
+
+func extractSchematic(path string, reader fileops.ReadableFile) (*grid.Grid[byte], error) {
 	file, err := fileops.OpenFile(path, reader)
 	if err != nil {
 		return nil, err
@@ -54,140 +138,98 @@ func extractSchematic(path string, reader fileops.ReadableFile) ([][]byte, error
 		_ = fileops.CloseFile(file)
 	}()
 
-	var schematic [][]byte
+	return grid.Parse(file)
+}
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := make([]byte, len(scanner.Bytes()))
-		copy(line, scanner.Bytes())
-		schematic = append(schematic, line)
+func validateRectangular(schematic *grid.Grid[byte]) error {
+	if schematic.IsRectangular() {
+		return nil
 	}
 
-	return schematic, nil
+	rows := schematic.Rows()
+
+	for rowIdx, row := range rows {
+		if len(row) != len(rows[0]) {
+			return &RaggedRowError{rowIdx + 1, len(row), len(rows[0])}
+		}
+	}
+
+	return nil
 }
 
-func extractTokens(schematic [][]byte) ([]schematicValue, []gear) {
+func extractTokens(schematic *grid.Grid[byte], gearSymbol byte) ([]schematicValue, []gear, partIndex) {
 	var values []schematicValue
 	var gears []gear
+	index := make(partIndex)
 
-	for rowIdx, row := range schematic {
+	for rowIdx, row := range schematic.Rows() {
 		var num string
 		var rowFound, colFound int
 
 		for colIdx, col := range row {
-			isGear := col == '*'
+			isGear := col == gearSymbol
 			isDigit := unicode.IsDigit(rune(col))
 			isNewNum := num == "" && isDigit
 			isRowEnding := colIdx == len(row)-1
-			hasNumEnded := num != "" && (!isDigit || isRowEnding)
 
 			if isGear {
 				gears = append(gears, gear{rowIdx, colIdx})
 			}
 
-			if isDigit {
-				num += string(col)
-			}
-
 			if isNewNum {
 				rowFound = rowIdx
 				colFound = colIdx
 			}
 
+			if isDigit {
+				num += string(col)
+			}
+
+			hasNumEnded := num != "" && (!isDigit || isRowEnding)
 			if hasNumEnded {
+				for offset := range len(num) {
+					index[grid.Point{Row: rowFound, Col: colFound + offset}] = len(values)
+				}
 				values = append(values, schematicValue{num, rowFound, colFound})
 				num = ""
 			}
 		}
 	}
 
-	return values, gears
+	return values, gears, index
 }
 
-func isAdjacentToSymbols(value schematicValue, schematic [][]byte) bool {
-	const symbols = "#$%&*+-/=@"
-
-	rowStart := max(value.row-1, 0)
-	rowEnd := min(value.row+1, len(schematic)-1)
+func isAdjacentToSymbols(value schematicValue, schematic *grid.Grid[byte], symbols SymbolPolicy) bool {
+	from := grid.Point{Row: value.row - 1, Col: value.col - 1}
+	to := grid.Point{Row: value.row + 1, Col: value.col + len(value.num)}
 
-	colStart := max(value.col-1, 0)
-	colEnd := min(value.col+len(value.num), len(schematic[0])-1)
-
-	found := false
-	for i := rowStart; i <= rowEnd; i++ {
-		if !found {
-			for j := colStart; j <= colEnd; j++ {
-				char := string(schematic[i][j])
-				if strings.Contains(symbols, char) {
-					found = true
-					break
-				}
-			}
-		} else {
-			break
+	for _, cell := range schematic.Box(from, to) {
+		if symbols.IsSymbol(cell) {
+			return true
 		}
 	}
 
-	return found
+	return false
 }
 
-func getGearRatioOrZero(coords gear, schematic [][]byte) int {
-	rowStart := max(coords.row-1, 0)
-	rowEnd := min(coords.row+1, len(schematic)-1)
-
-	colStart := max(coords.col-1, 0)
-	colEnd := min(coords.col+1, len(schematic[0])-1)
-
-	nums := make(map[schematicValue]bool)
-
-	for i := rowStart; i <= rowEnd; i++ {
-		for j := colStart; j <= colEnd; j++ {
-			char := schematic[i][j]
-			if unicode.IsDigit(rune(char)) {
-				value, _ := getValueFromCoordinates(i, j, schematic)
-				nums[value] = true
-			}
-		}
-	}
-
-	if len(nums) != 2 {
-		return 0
-	}
-
-	ratio := 1
-	for key := range nums {
-		num, _ := strconv.Atoi(key.num)
-		ratio *= num
-	}
-
-	return ratio
+func getGearRatioOrZero(coords gear, values []schematicValue, index partIndex, rule GearRule) int {
+	return rule.Aggregate(getGearParts(coords, values, index))
 }
 
-func getValueFromCoordinates(row int, col int, schematic [][]byte) (schematicValue, error) {
-	var toTheLeft, toTheRight string
-	var startingCol int
-	var err error = nil
+func getGearParts(coords gear, values []schematicValue, index partIndex) []int {
+	var seen []int
+	var parts []int
 
-	for i := col; i >= 0; i-- {
-		if !unicode.IsDigit(rune(schematic[row][i])) {
-			break
+	for _, direction := range grid.Surrounding {
+		valueIdx, ok := index[grid.Point{Row: coords.row, Col: coords.col}.Add(direction)]
+		if !ok || slices.Contains(seen, valueIdx) {
+			continue
 		}
-		toTheLeft = string(schematic[row][i:col])
-		startingCol = i
-	}
-
-	for i := col; i < len(schematic[row]); i++ {
-		if !unicode.IsDigit(rune(schematic[row][i])) {
-			break
-		}
-		toTheRight = string(schematic[row][col : i+1])
-	}
-
-	value := toTheLeft + toTheRight
 
-	if len(value) == 0 {
-		err = errors.New("no value found at provided coordinates")
+		seen = append(seen, valueIdx)
+		num, _ := strconv.Atoi(values[valueIdx].num)
+		parts = append(parts, num)
 	}
 
-	return schematicValue{value, row, startingCol}, err
+	return parts
 }
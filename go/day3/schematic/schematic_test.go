@@ -1,6 +1,7 @@
 package schematic
 
 import (
+	"adventOfCode/common/grid"
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
@@ -61,6 +62,66 @@ func TestTotalCalculationShould(t *testing.T) {
 
 }
 
+func TestLayoutShould(t *testing.T) {
+
+	t.Run("calculate totals using per row bounds for ragged rows", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "12\n..*\n...45"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		actualValues, actualRatios, err := CalculateTotals(fileName, mockReader)
+
+		assert.Nil(t, err, "Did not accept ragged rows")
+		assert.Equal(t, 12+45, actualValues, "Did not calculate the schematic values total correctly")
+		assert.Equal(t, 12*45, actualRatios, "Did not calculate the gear ratios total correctly")
+	})
+
+	t.Run("calculate totals for rectangular rows when required", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "467..114..\n...*......\n..35..633.\n......#..."
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		actual, _, err := CalculateTotals(fileName, mockReader, WithLayout(Rectangular))
+		expected := 467 + 35 + 633
+
+		assert.Nil(t, err, "Did not accept rectangular rows")
+		assert.Equal(t, expected, actual, "Did not calculate the schematic values total correctly")
+	})
+
+	t.Run("fail for ragged rows when rectangular rows are required", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "467..114..\n...*......\n..35..633\n......#..."
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		_, _, err := CalculateTotals(fileName, mockReader, WithLayout(Rectangular))
+
+		var raggedErr *RaggedRowError
+		assert.ErrorAs(t, err, &raggedErr, "Did not fail with a ragged row error")
+		assert.EqualError(t, err, "row 3 has length 9, expected 10", "Did not describe the ragged row")
+	})
+
+	t.Run("parse layouts", func(t *testing.T) {
+		ragged, _ := ParseLayout("ragged")
+		rectangular, _ := ParseLayout("rectangular")
+
+		assert.Equal(t, Ragged, ragged, "Did not parse ragged layout")
+		assert.Equal(t, Rectangular, rectangular, "Did not parse rectangular layout")
+	})
+
+	t.Run("fail to parse unknown layouts", func(t *testing.T) {
+		_, err := ParseLayout("hexagonal")
+
+		assert.EqualError(t, err, "unknown layout [hexagonal]", "Did not fail for unknown layout")
+	})
+
+}
+
 func TestSchematicExtractionShould(t *testing.T) {
 
 	t.Run("extract schematic", func(t *testing.T) {
@@ -76,7 +137,7 @@ func TestSchematicExtractionShould(t *testing.T) {
 		expected = append(expected, []byte{'A', 'B', 'C'})
 		expected = append(expected, []byte{'D', 'E', 'F'})
 
-		assert.Equal(t, expected, actual, "Did not extract schematic correctly")
+		assert.Equal(t, expected, actual.Rows(), "Did not extract schematic correctly")
 	})
 
 	t.Run("extract schematic values", func(t *testing.T) {
@@ -86,7 +147,7 @@ func TestSchematicExtractionShould(t *testing.T) {
 		schematic = append(schematic, []byte{'.', '.', '3', '5', '.', '.', '6', '3', '3', '.'})
 		schematic = append(schematic, []byte{'.', '.', '.', '.', '.', '.', '.', '#', '.', '.'})
 
-		actual, _ := extractTokens(schematic)
+		actual, _, _ := extractTokens(grid.FromRows(schematic), '*')
 		expected := []schematicValue{
 			{"467", 0, 0},
 			{"114", 0, 5},
@@ -97,6 +158,20 @@ func TestSchematicExtractionShould(t *testing.T) {
 		assert.Equal(t, expected, actual, "Did not extract schematic values correctly")
 	})
 
+	t.Run("extract single digit values ending a row", func(t *testing.T) {
+		var schematic [][]byte
+		schematic = append(schematic, []byte{'.', '.', '*', '7'})
+		schematic = append(schematic, []byte{'1', '2', '.', '.'})
+
+		actual, _, _ := extractTokens(grid.FromRows(schematic), '*')
+		expected := []schematicValue{
+			{"7", 0, 3},
+			{"12", 1, 0},
+		}
+
+		assert.Equal(t, expected, actual, "Did not extract single digit values correctly")
+	})
+
 	t.Run("extract gears", func(t *testing.T) {
 		var schematic [][]byte
 		schematic = append(schematic, []byte{'4', '6', '7', '.', '.', '1', '1', '4', '.', '.'})
@@ -104,7 +179,7 @@ func TestSchematicExtractionShould(t *testing.T) {
 		schematic = append(schematic, []byte{'.', '.', '3', '5', '.', '.', '.', '3', '3', '.'})
 		schematic = append(schematic, []byte{'.', '*', '.', '.', '.', '*', '.', '#', '.', '.'})
 
-		_, actual := extractTokens(schematic)
+		_, actual, _ := extractTokens(grid.FromRows(schematic), '*')
 		expected := []gear{
 			{1, 3},
 			{1, 7},
@@ -140,7 +215,7 @@ func TestAdjacencyDeterminationShould(t *testing.T) {
 			schematic = append(schematic, []byte{'.', '.', '5', '0', '1', '.', '1', '2', '.', '.'})
 			schematic = append(schematic, []byte{'.', '.', '.', '.', '.', '.', '/', '.', '.', '6'})
 
-			actual := isAdjacentToSymbols(test.value, schematic)
+			actual := isAdjacentToSymbols(test.value, grid.FromRows(schematic), DefaultSymbols())
 			expected := test.expected
 
 			assert.Equal(t, expected, actual, "Did not determine if adjacent to symbols")
@@ -170,7 +245,9 @@ func TestRatioDeterminationShould(t *testing.T) {
 			schematic = append(schematic, []byte{'.', '.', '5', '0', '1', '.', '1', '2', '.', '*'})
 			schematic = append(schematic, []byte{'*', '.', '.', '.', '.', '.', '/', '.', '.', '6'})
 
-			actual := getGearRatioOrZero(test.value, schematic)
+			values, _, index := extractTokens(grid.FromRows(schematic), '*')
+
+			actual := getGearRatioOrZero(test.value, values, index, DefaultGearRule())
 			expected := test.expected
 
 			assert.Equal(t, expected, actual, "Did not determine gear ratio correctly")
@@ -179,41 +256,58 @@ func TestRatioDeterminationShould(t *testing.T) {
 
 }
 
-func TestValueFromCoordinates(t *testing.T) {
+func TestPartIndexShould(t *testing.T) {
+
+	t.Run("keep identical numbers at different positions apart", func(t *testing.T) {
+		var schematic [][]byte
+		schematic = append(schematic, []byte{'1', '2', '.', '.'})
+		schematic = append(schematic, []byte{'.', '.', '*', '.'})
+		schematic = append(schematic, []byte{'.', '.', '.', '1', '2'})
+
+		values, gears, index := extractTokens(grid.FromRows(schematic), '*')
+
+		actual := getGearRatioOrZero(gears[0], values, index, DefaultGearRule())
+
+		assert.Equal(t, 12*12, actual, "Did not keep identical numbers apart")
+	})
 
 	tests := []struct {
 		row      int
 		col      int
 		expected string
-		isError  bool
+		found    bool
 	}{
-		{0, 0, "467", false},
-		{1, 5, "32", false},
-		{1, 9, "56", false},
-		{2, 3, "501", false},
-		{2, 6, "1", false},
-		{3, 9, "6", false},
-		{0, 3, "", true},
-		{0, 9, "", true},
-		{2, 5, "", true},
-		{3, 0, "", true},
-		{3, 8, "", true},
+		{0, 0, "467", true},
+		{0, 2, "467", true},
+		{1, 5, "32", true},
+		{1, 9, "56", true},
+		{2, 3, "501", true},
+		{2, 6, "1", true},
+		{3, 9, "6", true},
+		{0, 3, "", false},
+		{0, 9, "", false},
+		{2, 5, "", false},
+		{3, 0, "", false},
+		{3, 8, "", false},
+		{4, 0, "", false},
 	}
 
 	for _, test := range tests {
-		t.Run(fmt.Sprintf("get value from coordinates %d,%d", test.row, test.col), func(t *testing.T) {
+		t.Run(fmt.Sprintf("look up part number at %d,%d", test.row, test.col), func(t *testing.T) {
 			var schematic [][]byte
 			schematic = append(schematic, []byte{'4', '6', '7', '.', '.', '.', '.', '.', '.', '#'})
 			schematic = append(schematic, []byte{'.', '.', '.', '.', '*', '3', '2', '.', '5', '6'})
 			schematic = append(schematic, []byte{'.', '.', '5', '0', '1', '.', '1', '.', '.', '.'})
 			schematic = append(schematic, []byte{'.', '.', '.', '.', '.', '.', '/', '.', '.', '6'})
 
-			actual, err := getValueFromCoordinates(test.row, test.col, schematic)
-			expected := test.expected
-			isError := err != nil
+			values, _, index := extractTokens(grid.FromRows(schematic), '*')
+
+			valueIdx, ok := index[grid.Point{Row: test.row, Col: test.col}]
 
-			assert.Equal(t, test.isError, isError, "Did not get value from coordinates")
-			assert.Equal(t, expected, actual.num, "Did not get correct value from coordinates")
+			assert.Equal(t, test.found, ok, "Did not look up part number")
+			if ok {
+				assert.Equal(t, test.expected, values[valueIdx].num, "Did not look up correct part number")
+			}
 		})
 	}
 
@@ -253,7 +347,7 @@ func BenchmarkTotalsCalculation(b *testing.B) {
 		schematic = append(schematic, []byte{'.', '.', '.', '.', '.', '.', '.', '#', '.', '.'})
 
 		for i := 0; i < b.N; i++ {
-			_, _ = extractTokens(schematic)
+			_, _, _ = extractTokens(grid.FromRows(schematic), '*')
 		}
 	})
 
@@ -265,7 +359,7 @@ func BenchmarkTotalsCalculation(b *testing.B) {
 		schematic = append(schematic, []byte{'.', '*', '.', '.', '.', '*', '.', '#', '.', '.'})
 
 		for i := 0; i < b.N; i++ {
-			_, _ = extractTokens(schematic)
+			_, _, _ = extractTokens(grid.FromRows(schematic), '*')
 		}
 	})
 
@@ -277,7 +371,7 @@ func BenchmarkTotalsCalculation(b *testing.B) {
 		schematic = append(schematic, []byte{'.', '.', '.', '.', '.', '.', '/', '.', '.', '6'})
 
 		for i := 0; i < b.N; i++ {
-			isAdjacentToSymbols(schematicValue{"467", 0, 0}, schematic)
+			isAdjacentToSymbols(schematicValue{"467", 0, 0}, grid.FromRows(schematic), DefaultSymbols())
 		}
 	})
 
@@ -288,12 +382,14 @@ func BenchmarkTotalsCalculation(b *testing.B) {
 		schematic = append(schematic, []byte{'.', '.', '5', '0', '1', '.', '1', '2', '.', '*'})
 		schematic = append(schematic, []byte{'*', '.', '.', '.', '.', '.', '/', '.', '.', '6'})
 
+		values, _, index := extractTokens(grid.FromRows(schematic), '*')
+
 		for i := 0; i < b.N; i++ {
-			getGearRatioOrZero(gear{0, 9}, schematic)
+			getGearRatioOrZero(gear{0, 9}, values, index, DefaultGearRule())
 		}
 	})
 
-	b.Run("schematic value from coordinates extraction", func(b *testing.B) {
+	b.Run("part index extraction", func(b *testing.B) {
 		var schematic [][]byte
 		schematic = append(schematic, []byte{'4', '6', '7', '.', '.', '.', '.', '.', '.', '#'})
 		schematic = append(schematic, []byte{'.', '.', '.', '.', '*', '3', '2', '.', '5', '6'})
@@ -301,7 +397,7 @@ func BenchmarkTotalsCalculation(b *testing.B) {
 		schematic = append(schematic, []byte{'.', '.', '.', '.', '.', '.', '/', '.', '.', '6'})
 
 		for i := 0; i < b.N; i++ {
-			_, _ = getValueFromCoordinates(0, 0, schematic)
+			_, _, _ = extractTokens(grid.FromRows(schematic), '*')
 		}
 	})
 
@@ -0,0 +1,182 @@
+package schematic
+
+import (
+	"adventOfCode/common/fileops"
+	"adventOfCode/common/grid"
+	"bufio"
+	"io"
+	"slices"
+	"strconv"
+)
+
+type TokenKind int
+
+const (
+	PartToken TokenKind = iota
+	GearToken
+)
+
+type Token struct {
+	Kind  TokenKind
+	Row   int
+	Col   int
+	Value int
+}
+
+const maxStreamRowLength = 1 << 20
+
+type windowRow struct {
+	cells  []byte
+	values []schematicValue
+	gears  []gear
+}
+
+func WithStreaming() Option {
+	return func(s *settings) {
+		s.streaming = true
+	}
+}
+
+func Stream(reader io.Reader, emit func(Token), opts ...Option) error {
+	return streamTokens(reader, emit, newSettings(opts))
+}
+
+func calculateStreamingTotals(path string, reader fileops.ReadableFile, config settings) (int, int, error) {
+	file, err := fileops.OpenFile(path, reader)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	defer func() {
+		_ = fileops.CloseFile(file)
+	}()
+
+	valueTotal := 0
+	ratioTotal := 0
+
+	err = streamTokens(file, func(token Token) {
+		if token.Kind == PartToken {
+			valueTotal += token.Value
+		} else {
+			ratioTotal += token.Value
+		}
+	}, config)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	return valueTotal, ratioTotal, nil
+}
+
+func streamTokens(reader io.Reader, emit func(Token), config settings) error {
+	var window [3]*windowRow
+	seen := make(map[rune]bool)
+	firstLength := -1
+	rowIdx := 0
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxStreamRowLength)
+
+	for scanner.Scan() {
+		cells := slices.Clone(scanner.Bytes())
+
+		if firstLength < 0 {
+			firstLength = len(cells)
+		}
+		if config.layout == Rectangular && len(cells) != firstLength {
+			return &RaggedRowError{rowIdx + 1, len(cells), firstLength}
+		}
+
+		if err := checkSymbolInput(config.symbols, cells, rowIdx); err != nil {
+			return err
+		}
+
+		for _, symbol := range candidateSymbols(cells) {
+			seen[symbol] = true
+		}
+
+		window = [3]*windowRow{window[1], window[2], newWindowRow(cells, rowIdx, config.gearRule.Symbol)}
+		if window[1] != nil {
+			emitWindow(window, emit, config)
+		}
+		rowIdx++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	window = [3]*windowRow{window[1], window[2], nil}
+	if window[1] != nil {
+		emitWindow(window, emit, config)
+	}
+
+	found := make([]rune, 0, len(seen))
+	for symbol := range seen {
+		found = append(found, symbol)
+	}
+	slices.Sort(found)
+	config.symbolsFound(found)
+
+	return nil
+}
+
+func newWindowRow(cells []byte, rowIdx int, gearSymbol byte) *windowRow {
+	values, gears, _ := extractTokens(grid.FromRows([][]byte{cells}), gearSymbol)
+
+	for i := range values {
+		values[i].row = rowIdx
+	}
+	for i := range gears {
+		gears[i].row = rowIdx
+	}
+
+	return &windowRow{cells, values, gears}
+}
+
+func emitWindow(window [3]*windowRow, emit func(Token), config settings) {
+	current := window[1]
+
+	for _, value := range current.values {
+		if isAdjacentInWindow(value, window, config.symbols) {
+			num, _ := strconv.Atoi(value.num)
+			emit(Token{PartToken, value.row, value.col, num})
+		}
+	}
+
+	for _, gear := range current.gears {
+		var parts []int
+		for _, row := range window {
+			if row == nil {
+				continue
+			}
+
+			for _, value := range row.values {
+				if value.col <= gear.col+1 && value.col+len(value.num) >= gear.col {
+					num, _ := strconv.Atoi(value.num)
+					parts = append(parts, num)
+				}
+			}
+		}
+
+		if config.gearRule.Matches(len(parts)) {
+			emit(Token{GearToken, gear.row, gear.col, config.gearRule.Aggregate(parts)})
+		}
+	}
+}
+
+func isAdjacentInWindow(value schematicValue, window [3]*windowRow, symbols SymbolPolicy) bool {
+	for _, row := range window {
+		if row == nil {
+			continue
+		}
+
+		for col := max(value.col-1, 0); col <= value.col+len(value.num) && col < len(row.cells); col++ {
+			if symbols.IsSymbol(row.cells[col]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
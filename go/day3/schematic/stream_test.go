@@ -0,0 +1,176 @@
+package schematic
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+type lineReader struct {
+	lines  []string
+	served int
+}
+
+func (r *lineReader) Read(p []byte) (int, error) {
+	if r.served == len(r.lines) {
+		return 0, io.EOF
+	}
+
+	r.served++
+	return copy(p, r.lines[r.served-1]+"\n"), nil
+}
+
+type generatedReader struct {
+	rows    int
+	row     []byte
+	pending []byte
+}
+
+func (r *generatedReader) Read(p []byte) (int, error) {
+	if len(r.pending) == 0 {
+		if r.rows == 0 {
+			return 0, io.EOF
+		}
+
+		r.rows--
+		r.pending = r.row
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+
+	return n, nil
+}
+
+func randomSchematic(random *rand.Rand, height int, width int) string {
+	const cells = "........0123456789*#+$"
+
+	var builder strings.Builder
+	for range height {
+		for range width {
+			builder.WriteByte(cells[random.Intn(len(cells))])
+		}
+		builder.WriteByte('\n')
+	}
+
+	return builder.String()
+}
+
+func TestStreamingShould(t *testing.T) {
+
+	inputs := map[string]string{
+		"example": "467..114..\n...*......\n..35..633.\n......#...\n617*......\n.....+.58.\n..592.....\n......755.\n...$.*....\n.664.598..",
+		"ragged":  "467..114\n...*......\n..35..633.\n......#\n617*......\n.....+.58.\n..592\n......755.\n...$.*....\n.664.598..",
+		"single":  "12*34",
+	}
+
+	random := rand.New(rand.NewSource(3))
+	for i := range 20 {
+		inputs[fmt.Sprintf("random %d", i)] = randomSchematic(random, 1+random.Intn(12), 1+random.Intn(12))
+	}
+
+	rules := []GearRule{DefaultGearRule(), {'*', AtLeast, 1, Sum}, {'#', Exactly, 1, Max}}
+
+	for name, lines := range inputs {
+		for _, rule := range rules {
+			t.Run(fmt.Sprintf("calculate identical totals for %s with %v", name, rule), func(t *testing.T) {
+				const fileName = "test_input.txt"
+
+				memoryReader := new(MockFileReader)
+				memoryReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+				streamReader := new(MockFileReader)
+				streamReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+				expectedValues, expectedRatios, _ := CalculateTotals(fileName, memoryReader, WithGearRule(rule))
+				actualValues, actualRatios, err := CalculateTotals(fileName, streamReader, WithGearRule(rule), WithStreaming())
+
+				assert.Nil(t, err, "Did not stream totals")
+				assert.Equal(t, expectedValues, actualValues, "Did not stream schematic values total correctly")
+				assert.Equal(t, expectedRatios, actualRatios, "Did not stream gear ratios total correctly")
+			})
+		}
+	}
+
+	t.Run("emit tokens as soon as the row below is read", func(t *testing.T) {
+		reader := &lineReader{lines: []string{"467..114..", "...*......", "..35..633.", "......#..."}}
+
+		var tokens []Token
+		err := Stream(reader, func(token Token) {
+			assert.LessOrEqual(t, reader.served, token.Row+2, "Did not emit token before reading further rows")
+			tokens = append(tokens, token)
+		})
+
+		expected := []Token{
+			{PartToken, 0, 0, 467},
+			{GearToken, 1, 3, 467 * 35},
+			{PartToken, 2, 2, 35},
+			{PartToken, 2, 6, 633},
+		}
+
+		assert.Nil(t, err, "Did not stream tokens")
+		assert.Equal(t, expected, tokens, "Did not stream tokens correctly")
+	})
+
+	t.Run("fail for ragged rows when rectangular rows are required", func(t *testing.T) {
+		err := Stream(strings.NewReader("467..\n...*......"), func(Token) {}, WithLayout(Rectangular))
+
+		assert.EqualError(t, err, "row 2 has length 10, expected 5", "Did not fail for ragged rows")
+	})
+
+	t.Run("report distinct symbols found", func(t *testing.T) {
+		var actual []rune
+		_ = Stream(strings.NewReader("467..~\n...*..\n!.35.."), func(Token) {}, WithSymbolsFoundHandler(func(found []rune) {
+			actual = found
+		}))
+
+		assert.Equal(t, []rune("!*~"), actual, "Did not report distinct symbols")
+	})
+
+	t.Run("keep memory bounded for huge schematics", func(t *testing.T) {
+		const rows = 100_000
+		const row = "467..114..*..35..633.#..617*.....+.58...592.......755...$.*...664.598..467..114..*..35..633.#..617*\n"
+		const maxHeapGrowth = 2 << 20
+
+		var before runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		var peak uint64
+		parts := 0
+		err := Stream(&generatedReader{rows: rows, row: []byte(row)}, func(token Token) {
+			if token.Kind != PartToken {
+				return
+			}
+
+			parts++
+			if parts%100_000 == 0 {
+				var current runtime.MemStats
+				runtime.GC()
+				runtime.ReadMemStats(&current)
+				peak = max(peak, current.HeapAlloc)
+			}
+		})
+
+		assert.Nil(t, err, "Did not stream huge schematic")
+		assert.Greater(t, parts, rows, "Did not stream part numbers")
+		assert.Less(t, peak-min(peak, before.HeapAlloc), uint64(maxHeapGrowth), "Did not keep memory bounded")
+		assert.Greater(t, uint64(rows*len(row)), uint64(4*maxHeapGrowth), "Did not stream an input larger than the memory bound")
+	})
+
+}
+
+func BenchmarkStreaming(b *testing.B) {
+
+	b.Run("streaming totals calculation", func(b *testing.B) {
+		const lines = "467..114..\n...*......\n..35..633.\n......#..."
+
+		for i := 0; i < b.N; i++ {
+			_ = Stream(strings.NewReader(lines), func(Token) {})
+		}
+	})
+
+}
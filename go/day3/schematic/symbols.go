@@ -0,0 +1,141 @@
+package schematic
+
+import (
+	"adventOfCode/common/grid"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type SymbolPolicy interface {
+	IsSymbol(cell byte) bool
+}
+
+type SymbolSet string
+
+func (set SymbolSet) IsSymbol(cell byte) bool {
+	return strings.IndexByte(string(set), cell) >= 0
+}
+
+type NonDigitNonDot struct{}
+
+func (NonDigitNonDot) IsSymbol(cell byte) bool {
+	return isCandidateSymbol(cell)
+}
+
+type UnicodeCategories []string
+
+func (categories UnicodeCategories) IsSymbol(cell byte) bool {
+	if !isCandidateSymbol(cell) {
+		return false
+	}
+
+	for _, category := range categories {
+		if unicode.Is(unicode.Categories[category], rune(cell)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func DefaultSymbols() SymbolPolicy {
+	return SymbolSet("#$%&*+-/=@")
+}
+
+func ParseSymbolPolicy(spec string) (SymbolPolicy, error) {
+	kind, value, _ := strings.Cut(spec, ":")
+
+	switch kind {
+	case "default":
+		return DefaultSymbols(), nil
+	case "any":
+		return NonDigitNonDot{}, nil
+	case "set":
+		if value == "" {
+			return nil, fmt.Errorf("empty symbol set in [%s]", spec)
+		}
+
+		for _, r := range value {
+			if r >= utf8.RuneSelf {
+				return nil, fmt.Errorf("symbol sets cannot contain non-ASCII character [%c]", r)
+			}
+		}
+
+		return SymbolSet(value), nil
+	case "category":
+		categories := UnicodeCategories(strings.Split(value, ","))
+		for _, category := range categories {
+			if _, ok := unicode.Categories[category]; !ok {
+				return nil, fmt.Errorf("unknown unicode category [%s]", category)
+			}
+		}
+
+		return categories, nil
+	}
+
+	return nil, fmt.Errorf("unknown symbol policy [%s]", spec)
+}
+
+func WithSymbols(policy SymbolPolicy) Option {
+	return func(s *settings) {
+		s.symbols = policy
+	}
+}
+
+func WithSymbolsFoundHandler(handler func(found []rune)) Option {
+	return func(s *settings) {
+		s.symbolsFound = handler
+	}
+}
+
+func checkSymbolInput(policy SymbolPolicy, cells []byte, rowIdx int) error {
+	if _, ok := policy.(UnicodeCategories); !ok {
+		return nil
+	}
+
+	for colIdx := 0; colIdx < len(cells); colIdx++ {
+		if cells[colIdx] >= utf8.RuneSelf {
+			r, _ := utf8.DecodeRune(cells[colIdx:])
+			return fmt.Errorf("unicode categories cannot match non-ASCII character [%c] at row %d, col %d", r, rowIdx+1, colIdx+1)
+		}
+	}
+
+	return nil
+}
+
+func distinctSymbols(schematic *grid.Grid[byte]) []rune {
+	var found []rune
+
+	for _, row := range schematic.Rows() {
+		for _, symbol := range candidateSymbols(row) {
+			if !slices.Contains(found, symbol) {
+				found = append(found, symbol)
+			}
+		}
+	}
+
+	slices.Sort(found)
+
+	return found
+}
+
+func candidateSymbols(cells []byte) []rune {
+	var symbols []rune
+
+	for colIdx := 0; colIdx < len(cells); {
+		r, size := utf8.DecodeRune(cells[colIdx:])
+		if r >= utf8.RuneSelf || isCandidateSymbol(cells[colIdx]) {
+			symbols = append(symbols, r)
+		}
+		colIdx += size
+	}
+
+	return symbols
+}
+
+func isCandidateSymbol(cell byte) bool {
+	return cell < utf8.RuneSelf && cell != '.' && !unicode.IsDigit(rune(cell)) && !unicode.IsSpace(rune(cell))
+}
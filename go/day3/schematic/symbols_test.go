@@ -0,0 +1,162 @@
+package schematic
+
+import (
+	"adventOfCode/common/grid"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSymbolPolicyShould(t *testing.T) {
+
+	tests := []struct {
+		policy   SymbolPolicy
+		cell     byte
+		expected bool
+	}{
+		{DefaultSymbols(), '*', true},
+		{DefaultSymbols(), '~', false},
+		{DefaultSymbols(), '.', false},
+		{SymbolSet("~!"), '~', true},
+		{SymbolSet("~!"), '*', false},
+		{NonDigitNonDot{}, '~', true},
+		{NonDigitNonDot{}, '5', false},
+		{NonDigitNonDot{}, '.', false},
+		{UnicodeCategories{"Sm"}, '+', true},
+		{UnicodeCategories{"Sm"}, '*', false},
+		{UnicodeCategories{"P", "S"}, '*', true},
+		{UnicodeCategories{"P", "S"}, '.', false},
+		{UnicodeCategories{"P", "S"}, 'a', false},
+		{UnicodeCategories{"L"}, "×"[0], false},
+		{NonDigitNonDot{}, "×"[1], false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("classify %c with %v", test.cell, test.policy), func(t *testing.T) {
+			actual := test.policy.IsSymbol(test.cell)
+
+			assert.Equal(t, test.expected, actual, "Did not classify symbol correctly")
+		})
+	}
+
+}
+
+func TestSymbolPolicyParsingShould(t *testing.T) {
+
+	tests := []struct {
+		spec     string
+		expected SymbolPolicy
+	}{
+		{"default", DefaultSymbols()},
+		{"any", NonDigitNonDot{}},
+		{"set:~!", SymbolSet("~!")},
+		{"category:P,Sm", UnicodeCategories{"P", "Sm"}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("parse %s", test.spec), func(t *testing.T) {
+			actual, err := ParseSymbolPolicy(test.spec)
+
+			assert.Nil(t, err, "Did not parse symbol policy")
+			assert.Equal(t, test.expected, actual, "Did not parse symbol policy correctly")
+		})
+	}
+
+	failures := []struct {
+		spec     string
+		expected string
+	}{
+		{"emoji", "unknown symbol policy [emoji]"},
+		{"set:", "empty symbol set in [set:]"},
+		{"set:*×", "symbol sets cannot contain non-ASCII character [×]"},
+		{"category:P,Zz", "unknown unicode category [Zz]"},
+	}
+
+	for _, test := range failures {
+		t.Run(fmt.Sprintf("fail to parse %s", test.spec), func(t *testing.T) {
+			_, err := ParseSymbolPolicy(test.spec)
+
+			assert.EqualError(t, err, test.expected, "Did not fail to parse symbol policy")
+		})
+	}
+
+}
+
+func TestSymbolsShould(t *testing.T) {
+
+	t.Run("calculate totals with any non digit non dot symbol", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "467..114..\n...~......\n..35..633.\n......!..."
+
+		defaultReader := new(MockFileReader)
+		defaultReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+		anyReader := new(MockFileReader)
+		anyReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		defaultTotal, _, _ := CalculateTotals(fileName, defaultReader)
+		anyTotal, _, _ := CalculateTotals(fileName, anyReader, WithSymbols(NonDigitNonDot{}))
+
+		assert.Equal(t, 0, defaultTotal, "Did not ignore unknown symbols by default")
+		assert.Equal(t, 467+35+633, anyTotal, "Did not recognise any non digit non dot symbol")
+	})
+
+	t.Run("report distinct symbols found", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "467..114..\n...~......\n..35..633.\n......!.*~"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		var actual []rune
+		_, _, _ = CalculateTotals(fileName, mockReader, WithSymbolsFoundHandler(func(found []rune) {
+			actual = found
+		}))
+
+		assert.Equal(t, []rune("!*~"), actual, "Did not report distinct symbols")
+	})
+
+	t.Run("report non-ASCII symbols as whole characters", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "467×.114..\n...*......\n..35..633."
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		var actual []rune
+		_, _, err := CalculateTotals(fileName, mockReader, WithSymbols(NonDigitNonDot{}), WithSymbolsFoundHandler(func(found []rune) {
+			actual = found
+		}))
+
+		assert.Nil(t, err, "Did not calculate totals")
+		assert.Equal(t, []rune{'*', '×'}, actual, "Did not report non-ASCII symbols as whole characters")
+	})
+
+	t.Run("fail to match non-ASCII symbols against unicode categories", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "467..114..\n...×......\n..35..633."
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		_, _, err := CalculateTotals(fileName, mockReader, WithSymbols(UnicodeCategories{"Sm"}))
+
+		assert.EqualError(t, err, "unicode categories cannot match non-ASCII character [×] at row 2, col 4", "Did not reject non-ASCII symbols")
+	})
+
+	t.Run("fail to stream non-ASCII symbols against unicode categories", func(t *testing.T) {
+		err := Stream(strings.NewReader("467..114..\n...×......\n..35..633."), func(Token) {}, WithSymbols(UnicodeCategories{"Sm"}))
+
+		assert.EqualError(t, err, "unicode categories cannot match non-ASCII character [×] at row 2, col 4", "Did not reject non-ASCII symbols")
+	})
+
+	t.Run("find distinct symbols in schematic", func(t *testing.T) {
+		schematic := grid.FromRows([][]byte{[]byte("1.#"), []byte("@ #"), []byte("..9")})
+
+		actual := distinctSymbols(schematic)
+
+		assert.Equal(t, []rune("#@"), actual, "Did not find distinct symbols")
+	})
+
+}
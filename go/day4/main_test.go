@@ -60,6 +60,15 @@ func TestApplicationShould(t *testing.T) {
 		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
 	})
 
+	t.Run("fail for malformed cards", func(t *testing.T) {
+		os.Args = []string{"cmd", "testdata/malformed_input.txt"}
+
+		actualCode := captureErrorCode(main)
+		expectedCode := 2
+
+		assert.Equal(t, expectedCode, actualCode, "Did not exit with the expected code")
+	})
+
 }
 
 func captureErrorCode(f func()) int {
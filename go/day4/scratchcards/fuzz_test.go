@@ -0,0 +1,68 @@
+package scratchcards
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+const maxFuzzCards = 16
+
+func addTestdata(f *testing.F) {
+	content, err := os.ReadFile("../testdata/test_input.txt")
+	if err != nil {
+		return
+	}
+
+	f.Add(string(content))
+	for _, line := range strings.Split(string(content), "\n") {
+		f.Add(line)
+	}
+}
+
+func FuzzCardFromLine(f *testing.F) {
+	addTestdata(f)
+
+	f.Fuzz(func(t *testing.T, line string) {
+		card, err := cardFromLine(line)
+		if err != nil {
+			return
+		}
+
+		if matches := countMatches(card); matches < 0 || matches > len(card.winning) {
+			t.Errorf("card %v has %d matches", card, matches)
+		}
+		if score := determineScore(card); score < 0 {
+			t.Errorf("card %v has negative score %d", card, score)
+		}
+	})
+}
+
+func FuzzCalculateTotals(f *testing.F) {
+	addTestdata(f)
+
+	f.Fuzz(func(t *testing.T, input string) {
+		const fileName = "test_input.txt"
+
+		if strings.Count(input, "\n") >= maxFuzzCards {
+			t.Skip()
+		}
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(input)), nil)
+
+		score, count, err := CalculateTotals(fileName, mockReader)
+		if err != nil {
+			return
+		}
+
+		cards := len(strings.FieldsFunc(input, func(r rune) bool { return r == '\n' }))
+		if score < 0 {
+			t.Errorf("score %d is negative", score)
+		}
+		if count < cards {
+			t.Errorf("count %d is below the %d original cards", count, cards)
+		}
+	})
+}
@@ -3,12 +3,15 @@ package scratchcards
 import (
 	"adventOfCode/common/fileops"
 	"bufio"
+	"fmt"
 	"regexp"
 	"slices"
 	"strconv"
 	"strings"
 )
 
+var cardPattern = regexp.MustCompile(`^Card\s+(\d+):\s+([\d\s]+?)\s*\|\s*([\d\s]+)$`)
+
 type scratchcard struct {
 	id      int
 	winning []int
@@ -47,21 +50,41 @@ func extractScratchcards(path string, reader fileops.ReadableFile) ([]scratchcar
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		line := scanner.Text()
-		scratchcards = append(scratchcards, cardFromLine(line))
+
+		card, err := cardFromLine(line)
+		if err != nil {
+			return nil, err
+		}
+
+		if card.id != len(scratchcards)+1 {
+			return nil, fmt.Errorf("expected card %d, found card %d", len(scratchcards)+1, card.id)
+		}
+
+		scratchcards = append(scratchcards, card)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
 	}
 
 	return scratchcards, nil
 }
 
-func cardFromLine(line string) scratchcard {
-	regEx := regexp.MustCompile(`Card\s+(\d+):\s+([\d\s]+?)\s*\|\s*([\d\s]+)`)
-	match := regEx.FindStringSubmatch(line)
+func cardFromLine(line string) (scratchcard, error) {
+	match := cardPattern.FindStringSubmatch(line)
+	if match == nil {
+		return scratchcard{}, fmt.Errorf("malformed card [%s]", line)
+	}
+
+	id, err := strconv.Atoi(match[1])
+	if err != nil {
+		return scratchcard{}, fmt.Errorf("malformed card id [%s]", match[1])
+	}
 
-	id, _ := strconv.Atoi(match[1])
 	winning := toIntArray(match[2])
 	drawn := toIntArray(match[3])
 
-	return scratchcard{id, winning, drawn}
+	return scratchcard{id, winning, drawn}, nil
 }
 
 func toIntArray(line string) []int {
@@ -92,13 +115,15 @@ func determineScore(card scratchcard) int {
 }
 
 func addBonusCards(cards []scratchcard) []scratchcard {
+	originalCount := len(cards)
+
 	for i := 0; i < len(cards); i++ {
 		baseIdx := max(cards[i].id-1, 0)
 
 		score := countMatches(cards[baseIdx])
 
 		startIdx := baseIdx + 1
-		endIdx := min(baseIdx+1+score, len(cards))
+		endIdx := min(baseIdx+1+score, originalCount)
 
 		cards = append(cards, cards[startIdx:endIdx]...)
 	}
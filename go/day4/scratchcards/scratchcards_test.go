@@ -47,6 +47,20 @@ func TestTotalCalculationShould(t *testing.T) {
 		assert.Equal(t, expected, actual, "Did not calculate the count of bonus scratchcards correctly")
 	})
 
+	t.Run("stop copying scratchcards at the last card", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "Card 1: 1 2 | 1 3\nCard 2: 1 2 | 1 2"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		score, count, err := CalculateTotals(fileName, mockReader)
+
+		assert.Nil(t, err, "Did not calculate totals")
+		assert.Equal(t, 1+2, score, "Did not calculate the scratchcards total correctly")
+		assert.Equal(t, 2+1, count, "Did not stop copying scratchcards at the last card")
+	})
+
 	t.Run("fails when unable to read file", func(t *testing.T) {
 		const fileName = "test_input.txt"
 
@@ -82,12 +96,33 @@ func TestScratchcardExtractionShould(t *testing.T) {
 	t.Run("extract card from line", func(t *testing.T) {
 		const line = "Card 1: 41 48  | 83 41 6 31 17"
 
-		actual := cardFromLine(line)
+		actual, err := cardFromLine(line)
 		expected := scratchcard{1, []int{41, 48}, []int{83, 41, 6, 31, 17}}
 
+		assert.Nil(t, err, "Did not extract scratchcard from line")
 		assert.Equal(t, expected, actual, "Did not extract scratchcard from line")
 	})
 
+	t.Run("fail to extract card from malformed line", func(t *testing.T) {
+		const line = "Card 1: 41 48"
+
+		_, err := cardFromLine(line)
+
+		assert.EqualError(t, err, "malformed card [Card 1: 41 48]", "Did not fail for malformed line")
+	})
+
+	t.Run("fail to extract scratchcards out of order", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "Card 1: 41 48 | 83 41\nCard 3: 13 32 | 61 30"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		_, err := extractScratchcards(fileName, mockReader)
+
+		assert.EqualError(t, err, "expected card 2, found card 3", "Did not fail for cards out of order")
+	})
+
 	t.Run("convert to int array", func(t *testing.T) {
 		const line = " 83 41  6 31   17"
 
@@ -172,6 +207,23 @@ func TestBonusCountDeterminationShould(t *testing.T) {
 		assert.Equal(t, expected, actual, "Did not determine bonus cards correctly")
 	})
 
+	t.Run("not copy cards past the end of the table", func(t *testing.T) {
+		scratchcards := []scratchcard{
+			{1, []int{1, 2}, []int{1, 3}},
+			{2, []int{1, 2}, []int{1, 2}},
+		}
+
+		expected := []scratchcard{
+			{1, []int{1, 2}, []int{1, 3}},
+			{2, []int{1, 2}, []int{1, 2}},
+			{2, []int{1, 2}, []int{1, 2}},
+		}
+
+		actual := addBonusCards(scratchcards)
+
+		assert.Equal(t, expected, actual, "Did not stop copying at the end of the table")
+	})
+
 	tests := []struct {
 		card    scratchcard
 		matches int
@@ -224,7 +276,7 @@ func BenchmarkTotalsCalculation(b *testing.B) {
 		const line = "Card 1: 41 48  | 83 41 6 31 17"
 
 		for i := 0; i < b.N; i++ {
-			_ = cardFromLine(line)
+			_, _ = cardFromLine(line)
 		}
 	})
 
@@ -0,0 +1,211 @@
+package almanac
+
+import (
+	"adventOfCode/common/fileops"
+	"adventOfCode/common/interval"
+	"bufio"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var mapHeader = regexp.MustCompile(`^(\w+)-to-(\w+) map:$`)
+
+type Rule struct {
+	Source interval.Interval
+	Offset int
+}
+
+type Mapping struct {
+	From  string
+	To    string
+	Rules []Rule
+}
+
+type Almanac struct {
+	Seeds    []int
+	Mappings []Mapping
+}
+
+func CalculateLowestLocations(path string, reader fileops.ReadableFile) (seedLocation int, rangeLocation int, errorMsg error) {
+	almanac, err := extractAlmanac(path, reader)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	if len(almanac.Seeds) == 0 {
+		return -1, -1, errors.New("no seeds found")
+	}
+
+	chain, err := almanac.Chain("seed", "location")
+	if err != nil {
+		return -1, -1, err
+	}
+
+	seeds := make([]interval.Interval, len(almanac.Seeds))
+	for i, seed := range almanac.Seeds {
+		seeds[i] = interval.Interval{Start: seed, End: seed + 1}
+	}
+
+	ranges, err := seedRanges(almanac.Seeds)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	seedLocation, ok := applyChain(chain, interval.New(seeds...)).Min()
+	if !ok {
+		return -1, -1, fmt.Errorf("no seeds to locate")
+	}
+
+	rangeLocation, ok = applyChain(chain, interval.New(ranges...)).Min()
+	if !ok {
+		return -1, -1, fmt.Errorf("no seeds to locate")
+	}
+
+	return seedLocation, rangeLocation, nil
+}
+
+func (m Mapping) Apply(values interval.Set) interval.Set {
+	mapped := interval.New()
+	unmapped := values
+
+	for _, rule := range m.Rules {
+		source := interval.New(rule.Source)
+
+		mapped = mapped.Merge(unmapped.Intersect(source).Shift(rule.Offset))
+		unmapped = unmapped.Subtract(source)
+	}
+
+	return mapped.Merge(unmapped)
+}
+
+func (a Almanac) Chain(from string, to string) ([]Mapping, error) {
+	var chain []Mapping
+
+	for category := from; category != to; {
+		next, found := a.mappingFrom(category)
+		if !found {
+			return nil, fmt.Errorf("no mapping from [%s] towards [%s]", category, to)
+		}
+
+		if len(chain) == len(a.Mappings) {
+			return nil, fmt.Errorf("mappings from [%s] never reach [%s]", from, to)
+		}
+
+		chain = append(chain, next)
+		category = next.To
+	}
+
+	return chain, nil
+}
+
+func (a Almanac) mappingFrom(category string) (Mapping, bool) {
+	for _, mapping := range a.Mappings {
+		if mapping.From == category {
+			return mapping, true
+		}
+	}
+
+	return Mapping{}, false
+}
+
+func applyChain(chain []Mapping, values interval.Set) interval.Set {
+	for _, mapping := range chain {
+		values = mapping.Apply(values)
+	}
+
+	return values
+}
+
+func seedRanges(seeds []int) ([]interval.Interval, error) {
+	if len(seeds)%2 != 0 {
+		return nil, errors.New("seed ranges need an even number of values")
+	}
+
+	ranges := make([]interval.Interval, 0, len(seeds)/2)
+	for i := 0; i < len(seeds); i += 2 {
+		ranges = append(ranges, interval.Interval{Start: seeds[i], End: seeds[i] + seeds[i+1]})
+	}
+
+	return ranges, nil
+}
+
+func extractAlmanac(path string, reader fileops.ReadableFile) (Almanac, error) {
+	file, err := fileops.OpenFile(path, reader)
+	if err != nil {
+		return Almanac{}, err
+	}
+
+	defer func() {
+		_ = fileops.CloseFile(file)
+	}()
+
+	var almanac Almanac
+	var current *Mapping
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			current = nil
+		case strings.HasPrefix(line, "seeds:"):
+			seeds, err := toIntArray(strings.TrimPrefix(line, "seeds:"))
+			if err != nil {
+				return Almanac{}, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			almanac.Seeds = seeds
+		case mapHeader.MatchString(line):
+			match := mapHeader.FindStringSubmatch(line)
+			almanac.Mappings = append(almanac.Mappings, Mapping{From: match[1], To: match[2]})
+			current = &almanac.Mappings[len(almanac.Mappings)-1]
+		case current != nil:
+			rule, err := ruleFromLine(line)
+			if err != nil {
+				return Almanac{}, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			current.Rules = append(current.Rules, rule)
+		default:
+			return Almanac{}, fmt.Errorf("line %d: unexpected content [%s]", lineNumber, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Almanac{}, err
+	}
+
+	return almanac, nil
+}
+
+func ruleFromLine(line string) (Rule, error) {
+	values, err := toIntArray(line)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	if len(values) != 3 {
+		return Rule{}, fmt.Errorf("expected destination, source and length in [%s]", line)
+	}
+
+	destination, source, length := values[0], values[1], values[2]
+
+	return Rule{interval.Interval{Start: source, End: source + length}, destination - source}, nil
+}
+
+func toIntArray(line string) ([]int, error) {
+	parts := strings.Fields(line)
+
+	nums := make([]int, len(parts))
+	for i, num := range parts {
+		value, err := strconv.Atoi(num)
+		if err != nil || value < 0 {
+			return nil, fmt.Errorf("invalid number [%s]", num)
+		}
+		nums[i] = value
+	}
+
+	return nums, nil
+}
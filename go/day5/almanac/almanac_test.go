@@ -0,0 +1,161 @@
+package almanac
+
+import (
+	"adventOfCode/common/interval"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"strings"
+	"testing"
+)
+
+type MockFileReader struct {
+	mock.Mock
+}
+
+func (mockReader *MockFileReader) Open(path string) (io.ReadCloser, error) {
+	args := mockReader.Called(path)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+const example = "seeds: 79 14 55 13\n\n" +
+	"seed-to-soil map:\n50 98 2\n52 50 48\n\n" +
+	"soil-to-fertilizer map:\n0 15 37\n37 52 2\n39 0 15\n\n" +
+	"fertilizer-to-water map:\n49 53 8\n0 11 42\n42 0 7\n57 7 4\n\n" +
+	"water-to-light map:\n88 18 7\n18 25 70\n\n" +
+	"light-to-temperature map:\n45 77 23\n81 45 19\n68 64 13\n\n" +
+	"temperature-to-humidity map:\n0 69 1\n1 0 69\n\n" +
+	"humidity-to-location map:\n60 56 37\n56 93 4\n"
+
+func TestLowestLocationCalculationShould(t *testing.T) {
+
+	t.Run("calculate lowest location for seeds", func(t *testing.T) {
+		const fileName = "test_input.txt"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(example)), nil)
+
+		actual, _, err := CalculateLowestLocations(fileName, mockReader)
+
+		assert.Nil(t, err, "Did not calculate lowest location")
+		assert.Equal(t, 35, actual, "Did not calculate lowest location for seeds correctly")
+	})
+
+	t.Run("calculate lowest location for seed ranges", func(t *testing.T) {
+		const fileName = "test_input.txt"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(example)), nil)
+
+		_, actual, err := CalculateLowestLocations(fileName, mockReader)
+
+		assert.Nil(t, err, "Did not calculate lowest location")
+		assert.Equal(t, 46, actual, "Did not calculate lowest location for seed ranges correctly")
+	})
+
+	t.Run("calculate lowest location for huge seed ranges", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "seeds: 0 4000000000\n\nseed-to-location map:\n100 0 10\n0 3999999990 10\n"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		_, actual, err := CalculateLowestLocations(fileName, mockReader)
+
+		assert.Nil(t, err, "Did not calculate lowest location")
+		assert.Equal(t, 0, actual, "Did not calculate lowest location without expanding seeds")
+	})
+
+	failures := []struct {
+		name     string
+		lines    string
+		expected string
+	}{
+		{"no seeds", "seed-to-location map:\n1 2 3\n", "no seeds found"},
+		{"empty seed ranges", "seeds: 1 0 5 0\n\nseed-to-location map:\n1 2 3\n", "no seeds to locate"},
+		{"odd seed ranges", "seeds: 1 2 3\n\nseed-to-location map:\n1 2 3\n", "seed ranges need an even number of values"},
+		{"broken chains", "seeds: 1 2\n\nseed-to-soil map:\n1 2 3\n", "no mapping from [soil] towards [location]"},
+		{"cyclic chains", "seeds: 1 2\n\nseed-to-soil map:\n1 2 3\n\nsoil-to-seed map:\n1 2 3\n", "mappings from [seed] never reach [location]"},
+		{"malformed rules", "seeds: 1 2\n\nseed-to-location map:\n1 2\n", "line 4: expected destination, source and length in [1 2]"},
+		{"invalid numbers", "seeds: 1 x\n", "line 1: invalid number [x]"},
+		{"unexpected content", "seeds: 1 2\n\n1 2 3\n", "line 3: unexpected content [1 2 3]"},
+	}
+
+	for _, test := range failures {
+		t.Run("fail for "+test.name, func(t *testing.T) {
+			const fileName = "test_input.txt"
+
+			mockReader := new(MockFileReader)
+			mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(test.lines)), nil)
+
+			_, _, err := CalculateLowestLocations(fileName, mockReader)
+
+			assert.EqualError(t, err, test.expected, "Did not fail for "+test.name)
+		})
+	}
+
+	t.Run("fail when unable to read file", func(t *testing.T) {
+		const fileName = "test_input.txt"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader("")), errors.New("file open error"))
+
+		_, _, err := CalculateLowestLocations(fileName, mockReader)
+
+		assert.EqualError(t, err, "file open error", "Did not fail when unable to read file")
+	})
+
+}
+
+func TestMappingShould(t *testing.T) {
+
+	t.Run("map values through rules and keep unmapped values", func(t *testing.T) {
+		mapping := Mapping{"seed", "soil", []Rule{
+			{interval.Interval{Start: 98, End: 100}, -48},
+			{interval.Interval{Start: 50, End: 98}, 2},
+		}}
+
+		actual := mapping.Apply(interval.New(interval.Interval{Start: 40, End: 60}, interval.Interval{Start: 95, End: 105}))
+		expected := []interval.Interval{{Start: 40, End: 50}, {Start: 50, End: 52}, {Start: 52, End: 62}, {Start: 97, End: 105}}
+
+		assert.Equal(t, interval.New(expected...).Intervals(), actual.Intervals(), "Did not map values")
+	})
+
+	t.Run("give earlier rules precedence", func(t *testing.T) {
+		mapping := Mapping{"seed", "soil", []Rule{
+			{interval.Interval{Start: 0, End: 10}, 100},
+			{interval.Interval{Start: 5, End: 15}, 200},
+		}}
+
+		actual := mapping.Apply(interval.New(interval.Interval{Start: 0, End: 15}))
+		expected := []interval.Interval{{Start: 100, End: 110}, {Start: 210, End: 215}}
+
+		assert.Equal(t, expected, actual.Intervals(), "Did not give earlier rules precedence")
+	})
+
+	t.Run("chain mappings between categories", func(t *testing.T) {
+		almanac := Almanac{nil, []Mapping{{"soil", "location", nil}, {"seed", "soil", nil}}}
+
+		actual, err := almanac.Chain("seed", "location")
+
+		assert.Nil(t, err, "Did not chain mappings")
+		assert.Equal(t, []Mapping{{"seed", "soil", nil}, {"soil", "location", nil}}, actual, "Did not chain mappings in order")
+	})
+
+}
+
+func BenchmarkLowestLocationCalculation(b *testing.B) {
+
+	b.Run("lowest location calculation", func(b *testing.B) {
+		const fileName = "test_input.txt"
+
+		for i := 0; i < b.N; i++ {
+			mockReader := new(MockFileReader)
+			mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(example)), nil)
+
+			_, _, _ = CalculateLowestLocations(fileName, mockReader)
+		}
+	})
+
+}
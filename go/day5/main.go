@@ -0,0 +1,33 @@
+package main
+
+import (
+	"adventOfCode/common/fileops"
+	"adventOfCode/common/validation"
+	"adventOfCode/day5/almanac"
+	"fmt"
+	"log"
+	"os"
+)
+
+var osExit = os.Exit
+
+func main() {
+	log.SetFlags(0)
+
+	path, err := validation.ExtractSingleArgIgnoringOthers(os.Args, 2)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	seedLocation, rangeLocation, err := almanac.CalculateLowestLocations(path, &fileops.FileReader{})
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(2)
+		return
+	}
+
+	fmt.Printf("The lowest location for the seeds is %d\n", seedLocation)
+	fmt.Printf("The lowest location for the seed ranges is %d\n", rangeLocation)
+}
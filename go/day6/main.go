@@ -0,0 +1,33 @@
+package main
+
+import (
+	"adventOfCode/common/fileops"
+	"adventOfCode/common/validation"
+	"adventOfCode/day6/races"
+	"fmt"
+	"log"
+	"os"
+)
+
+var osExit = os.Exit
+
+func main() {
+	log.SetFlags(0)
+
+	path, err := validation.ExtractSingleArgIgnoringOthers(os.Args, 2)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	waysProduct, combinedWays, err := races.CalculateWays(path, &fileops.FileReader{})
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(2)
+		return
+	}
+
+	fmt.Printf("The product of the ways to win each race is %d\n", waysProduct)
+	fmt.Printf("The number of ways to win the combined race is %d\n", combinedWays)
+}
@@ -0,0 +1,134 @@
+package races
+
+import (
+	"adventOfCode/common/fileops"
+	"bufio"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type race struct {
+	time     int
+	distance int
+}
+
+func CalculateWays(path string, reader fileops.ReadableFile) (product int, combinedWays int, errorMsg error) {
+	races, combined, err := extractRaces(path, reader)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	product = 1
+	for _, r := range races {
+		product *= winningHoldTimes(r)
+	}
+
+	return product, winningHoldTimes(combined), nil
+}
+
+func extractRaces(path string, reader fileops.ReadableFile) ([]race, race, error) {
+	file, err := fileops.OpenFile(path, reader)
+	if err != nil {
+		return nil, race{}, err
+	}
+
+	defer func() {
+		_ = fileops.CloseFile(file)
+	}()
+
+	fields := make(map[string][]string)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		name, values, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+
+		fields[strings.TrimSpace(name)] = strings.Fields(values)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, race{}, err
+	}
+
+	times, distances := fields["Time"], fields["Distance"]
+	if len(times) == 0 || len(times) != len(distances) {
+		return nil, race{}, fmt.Errorf("expected matching times and distances, got %d and %d", len(times), len(distances))
+	}
+
+	races := make([]race, len(times))
+	for i := range times {
+		races[i].time, err = toInt(times[i])
+		if err != nil {
+			return nil, race{}, err
+		}
+
+		races[i].distance, err = toInt(distances[i])
+		if err != nil {
+			return nil, race{}, err
+		}
+	}
+
+	combinedTime, err := toInt(strings.Join(times, ""))
+	if err != nil {
+		return nil, race{}, err
+	}
+
+	combinedDistance, err := toInt(strings.Join(distances, ""))
+	if err != nil {
+		return nil, race{}, err
+	}
+
+	return races, race{combinedTime, combinedDistance}, nil
+}
+
+func toInt(value string) (int, error) {
+	num, err := strconv.Atoi(value)
+	if err != nil || num < 0 {
+		return -1, fmt.Errorf("invalid number [%s]", value)
+	}
+
+	return num, nil
+}
+
+func winningHoldTimes(r race) int {
+	time := big.NewInt(int64(r.time))
+	distance := big.NewInt(int64(r.distance))
+
+	discriminant := new(big.Int).Mul(time, time)
+	discriminant.Sub(discriminant, new(big.Int).Lsh(distance, 2))
+	if discriminant.Sign() < 0 {
+		return 0
+	}
+
+	root := new(big.Int).Sqrt(discriminant)
+	shortest := new(big.Int).Sub(time, root)
+	shortest.Rsh(shortest, 1)
+
+	for shortest.Sign() > 0 && beats(new(big.Int).Sub(shortest, big.NewInt(1)), time, distance) {
+		shortest.Sub(shortest, big.NewInt(1))
+	}
+	for !beats(shortest, time, distance) {
+		shortest.Add(shortest, big.NewInt(1))
+
+		if new(big.Int).Lsh(shortest, 1).Cmp(time) > 0 {
+			return 0
+		}
+	}
+
+	longest := new(big.Int).Sub(time, shortest)
+	ways := longest.Sub(longest, shortest)
+	ways.Add(ways, big.NewInt(1))
+
+	return int(ways.Int64())
+}
+
+func beats(hold *big.Int, time *big.Int, distance *big.Int) bool {
+	travelled := new(big.Int).Sub(time, hold)
+	travelled.Mul(travelled, hold)
+
+	return travelled.Cmp(distance) > 0
+}
@@ -0,0 +1,142 @@
+package races
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"strings"
+	"testing"
+)
+
+type MockFileReader struct {
+	mock.Mock
+}
+
+func (mockReader *MockFileReader) Open(path string) (io.ReadCloser, error) {
+	args := mockReader.Called(path)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func bruteForceWinningHoldTimes(r race) int {
+	ways := 0
+	for hold := 0; hold <= r.time; hold++ {
+		if hold*(r.time-hold) > r.distance {
+			ways++
+		}
+	}
+
+	return ways
+}
+
+func TestWaysCalculationShould(t *testing.T) {
+
+	t.Run("calculate product of ways to win", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "Time:      7  15   30\nDistance:  9  40  200"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		actual, _, err := CalculateWays(fileName, mockReader)
+
+		assert.Nil(t, err, "Did not calculate ways to win")
+		assert.Equal(t, 4*8*9, actual, "Did not calculate product of ways to win correctly")
+	})
+
+	t.Run("calculate ways to win the combined race", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "Time:      7  15   30\nDistance:  9  40  200"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		_, actual, err := CalculateWays(fileName, mockReader)
+
+		assert.Nil(t, err, "Did not calculate ways to win")
+		assert.Equal(t, 71503, actual, "Did not calculate ways to win the combined race correctly")
+	})
+
+	failures := []struct {
+		name     string
+		lines    string
+		expected string
+	}{
+		{"missing distances", "Time: 7 15\n", "expected matching times and distances, got 2 and 0"},
+		{"mismatched races", "Time: 7 15\nDistance: 9\n", "expected matching times and distances, got 2 and 1"},
+		{"invalid numbers", "Time: 7 x\nDistance: 9 40\n", "invalid number [x]"},
+		{"negative numbers", "Time: 7 15\nDistance: 9 -40\n", "invalid number [-40]"},
+		{"combined races that are too long", "Time: 9999999999 9999999999\nDistance: 1 1\n", "invalid number [99999999999999999999]"},
+	}
+
+	for _, test := range failures {
+		t.Run("fail for "+test.name, func(t *testing.T) {
+			const fileName = "test_input.txt"
+
+			mockReader := new(MockFileReader)
+			mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(test.lines)), nil)
+
+			_, _, err := CalculateWays(fileName, mockReader)
+
+			assert.EqualError(t, err, test.expected, "Did not fail for "+test.name)
+		})
+	}
+
+	t.Run("fail when unable to read file", func(t *testing.T) {
+		const fileName = "test_input.txt"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader("")), errors.New("file open error"))
+
+		_, _, err := CalculateWays(fileName, mockReader)
+
+		assert.EqualError(t, err, "file open error", "Did not fail when unable to read file")
+	})
+
+}
+
+func TestWinningHoldTimesShould(t *testing.T) {
+
+	t.Run("agree with brute force for small races", func(t *testing.T) {
+		for time := 0; time <= 60; time++ {
+			for distance := 0; distance <= time*time/4+2; distance++ {
+				r := race{time, distance}
+
+				assert.Equal(t, bruteForceWinningHoldTimes(r), winningHoldTimes(r), fmt.Sprintf("Did not agree with brute force for %v", r))
+			}
+		}
+	})
+
+	tests := []struct {
+		race     race
+		expected int
+	}{
+		{race{71530, 940200}, 71503},
+		{race{10, 25}, 0},
+		{race{10, 24}, 1},
+		{race{3037000500, 2305843009250062499}, 1},
+		{race{3037000500, 2305843009250062500}, 0},
+		{race{3037000499, 2305843007731562249}, 2},
+		{race{4000000000, 1}, 3999999999},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("count winning hold times for %v", test.race), func(t *testing.T) {
+			actual := winningHoldTimes(test.race)
+
+			assert.Equal(t, test.expected, actual, "Did not count winning hold times correctly")
+		})
+	}
+
+}
+
+func BenchmarkWaysCalculation(b *testing.B) {
+
+	b.Run("winning hold times", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			winningHoldTimes(race{71530, 940200})
+		}
+	})
+
+}
@@ -0,0 +1,216 @@
+package camelcards
+
+import (
+	"adventOfCode/common/fileops"
+	"bufio"
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type HandType int
+
+const (
+	HighCard HandType = iota
+	OnePair
+	TwoPair
+	ThreeOfAKind
+	FullHouse
+	FourOfAKind
+	FiveOfAKind
+)
+
+var handTypeNames = map[HandType]string{
+	HighCard:     "high card",
+	OnePair:      "one pair",
+	TwoPair:      "two pair",
+	ThreeOfAKind: "three of a kind",
+	FullHouse:    "full house",
+	FourOfAKind:  "four of a kind",
+	FiveOfAKind:  "five of a kind",
+}
+
+func (t HandType) String() string {
+	return handTypeNames[t]
+}
+
+const handSize = 5
+
+const cards = "23456789TJQKA"
+
+type Hand struct {
+	Cards string
+	Bid   int
+}
+
+type RankingRule interface {
+	Classify(cards string) HandType
+	Strength(card byte) int
+}
+
+type StandardRule struct{}
+
+func (StandardRule) Classify(cards string) HandType {
+	return classifyCounts(cardCounts(cards), 0)
+}
+
+func (StandardRule) Strength(card byte) int {
+	return strings.IndexByte(cards, card)
+}
+
+type JokerRule struct{}
+
+func (JokerRule) Classify(cards string) HandType {
+	counts := cardCounts(cards)
+	jokers := counts['J']
+	delete(counts, 'J')
+
+	return classifyCounts(counts, jokers)
+}
+
+func (JokerRule) Strength(card byte) int {
+	if card == 'J' {
+		return -1
+	}
+
+	return strings.IndexByte(cards, card)
+}
+
+func CalculateWinnings(path string, reader fileops.ReadableFile) (standard int, jokers int, errorMsg error) {
+	hands, err := extractHands(path, reader)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	return Winnings(hands, StandardRule{}), Winnings(hands, JokerRule{}), nil
+}
+
+func Winnings(hands []Hand, rule RankingRule) int {
+	total := 0
+	for rank, hand := range Rank(hands, rule) {
+		total += (rank + 1) * hand.Bid
+	}
+
+	return total
+}
+
+func Rank(hands []Hand, rule RankingRule) []Hand {
+	ranked := slices.Clone(hands)
+
+	slices.SortStableFunc(ranked, func(a Hand, b Hand) int {
+		return Compare(a.Cards, b.Cards, rule)
+	})
+
+	return ranked
+}
+
+func Compare(a string, b string, rule RankingRule) int {
+	if order := cmp.Compare(rule.Classify(a), rule.Classify(b)); order != 0 {
+		return order
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if order := cmp.Compare(rule.Strength(a[i]), rule.Strength(b[i])); order != 0 {
+			return order
+		}
+	}
+
+	return 0
+}
+
+func cardCounts(hand string) map[byte]int {
+	counts := make(map[byte]int)
+	for i := 0; i < len(hand); i++ {
+		counts[hand[i]]++
+	}
+
+	return counts
+}
+
+func classifyCounts(counts map[byte]int, wildcards int) HandType {
+	var groups []int
+	for _, count := range counts {
+		groups = append(groups, count)
+	}
+	slices.SortFunc(groups, func(a int, b int) int {
+		return cmp.Compare(b, a)
+	})
+
+	for len(groups) < 2 {
+		groups = append(groups, 0)
+	}
+	groups[0] += wildcards
+
+	switch {
+	case groups[0] == 5:
+		return FiveOfAKind
+	case groups[0] == 4:
+		return FourOfAKind
+	case groups[0] == 3 && groups[1] == 2:
+		return FullHouse
+	case groups[0] == 3:
+		return ThreeOfAKind
+	case groups[0] == 2 && groups[1] == 2:
+		return TwoPair
+	case groups[0] == 2:
+		return OnePair
+	}
+
+	return HighCard
+}
+
+func extractHands(path string, reader fileops.ReadableFile) ([]Hand, error) {
+	file, err := fileops.OpenFile(path, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = fileops.CloseFile(file)
+	}()
+
+	var hands []Hand
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		hand, err := handFromLine(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		hands = append(hands, hand)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return hands, nil
+}
+
+func handFromLine(line string) (Hand, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 2 {
+		return Hand{}, fmt.Errorf("expected cards and bid in [%s]", line)
+	}
+
+	hand := fields[0]
+	if len(hand) != handSize {
+		return Hand{}, fmt.Errorf("expected %d cards in [%s]", handSize, hand)
+	}
+
+	for i := 0; i < len(hand); i++ {
+		if strings.IndexByte(cards, hand[i]) < 0 {
+			return Hand{}, fmt.Errorf("unknown card [%c] in [%s]", hand[i], hand)
+		}
+	}
+
+	bid, err := strconv.Atoi(fields[1])
+	if err != nil || bid < 0 {
+		return Hand{}, fmt.Errorf("invalid bid [%s]", fields[1])
+	}
+
+	return Hand{hand, bid}, nil
+}
@@ -0,0 +1,268 @@
+package camelcards
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"strings"
+	"testing"
+)
+
+type MockFileReader struct {
+	mock.Mock
+}
+
+func (mockReader *MockFileReader) Open(path string) (io.ReadCloser, error) {
+	args := mockReader.Called(path)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+const exampleHands = "32T3K 765\nT55J5 684\nKK677 28\nKTJJT 220\nQQQJA 483"
+
+func allHands(alphabet string, size int) []string {
+	hands := []string{""}
+	for range size {
+		var next []string
+		for _, hand := range hands {
+			for i := 0; i < len(alphabet); i++ {
+				next = append(next, hand+alphabet[i:i+1])
+			}
+		}
+		hands = next
+	}
+
+	return hands
+}
+
+func bestSubstitution(hand string) HandType {
+	i := strings.IndexByte(hand, 'J')
+	if i < 0 {
+		return StandardRule{}.Classify(hand)
+	}
+
+	best := HighCard
+	for _, card := range strings.ReplaceAll(cards, "J", "") {
+		best = max(best, bestSubstitution(hand[:i]+string(card)+hand[i+1:]))
+	}
+
+	return best
+}
+
+func TestWinningsCalculationShould(t *testing.T) {
+
+	t.Run("calculate winnings", func(t *testing.T) {
+		const fileName = "test_input.txt"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(exampleHands)), nil)
+
+		actual, _, err := CalculateWinnings(fileName, mockReader)
+
+		assert.Nil(t, err, "Did not calculate winnings")
+		assert.Equal(t, 6440, actual, "Did not calculate winnings correctly")
+	})
+
+	t.Run("calculate winnings with jokers", func(t *testing.T) {
+		const fileName = "test_input.txt"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(exampleHands)), nil)
+
+		_, actual, err := CalculateWinnings(fileName, mockReader)
+
+		assert.Nil(t, err, "Did not calculate winnings")
+		assert.Equal(t, 5905, actual, "Did not calculate winnings with jokers correctly")
+	})
+
+	failures := []struct {
+		name     string
+		lines    string
+		expected string
+	}{
+		{"missing bids", "32T3K\n", "line 1: expected cards and bid in [32T3K]"},
+		{"short hands", "32T3K 765\n32T3 765\n", "line 2: expected 5 cards in [32T3]"},
+		{"unknown cards", "32T3X 765\n", "line 1: unknown card [X] in [32T3X]"},
+		{"invalid bids", "32T3K bid\n", "line 1: invalid bid [bid]"},
+		{"negative bids", "32T3K -765\n", "line 1: invalid bid [-765]"},
+	}
+
+	for _, test := range failures {
+		t.Run("fail for "+test.name, func(t *testing.T) {
+			const fileName = "test_input.txt"
+
+			mockReader := new(MockFileReader)
+			mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(test.lines)), nil)
+
+			_, _, err := CalculateWinnings(fileName, mockReader)
+
+			assert.EqualError(t, err, test.expected, "Did not fail for "+test.name)
+		})
+	}
+
+	t.Run("fail when unable to read file", func(t *testing.T) {
+		const fileName = "test_input.txt"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader("")), errors.New("file open error"))
+
+		_, _, err := CalculateWinnings(fileName, mockReader)
+
+		assert.EqualError(t, err, "file open error", "Did not fail when unable to read file")
+	})
+
+}
+
+func TestHandClassificationShould(t *testing.T) {
+
+	shapes := []struct {
+		hand     string
+		standard HandType
+		joker    HandType
+	}{
+		{"23456", HighCard, HighCard},
+		{"J3456", HighCard, OnePair},
+		{"22345", OnePair, OnePair},
+		{"22J45", OnePair, ThreeOfAKind},
+		{"JJ345", OnePair, ThreeOfAKind},
+		{"22335", TwoPair, TwoPair},
+		{"2233J", TwoPair, FullHouse},
+		{"22JJ5", TwoPair, FourOfAKind},
+		{"22234", ThreeOfAKind, ThreeOfAKind},
+		{"222J4", ThreeOfAKind, FourOfAKind},
+		{"JJJ34", ThreeOfAKind, FourOfAKind},
+		{"22233", FullHouse, FullHouse},
+		{"222JJ", FullHouse, FiveOfAKind},
+		{"JJJ33", FullHouse, FiveOfAKind},
+		{"22223", FourOfAKind, FourOfAKind},
+		{"2222J", FourOfAKind, FiveOfAKind},
+		{"JJJJ3", FourOfAKind, FiveOfAKind},
+		{"22222", FiveOfAKind, FiveOfAKind},
+		{"JJJJJ", FiveOfAKind, FiveOfAKind},
+	}
+
+	for _, test := range shapes {
+		t.Run(fmt.Sprintf("classify %s as %s and %s with jokers", test.hand, test.standard, test.joker), func(t *testing.T) {
+			assert.Equal(t, test.standard, StandardRule{}.Classify(test.hand), "Did not classify hand correctly")
+			assert.Equal(t, test.joker, JokerRule{}.Classify(test.hand), "Did not classify hand with jokers correctly")
+		})
+	}
+
+	short := []struct {
+		hand     string
+		standard HandType
+		joker    HandType
+	}{
+		{"", HighCard, HighCard},
+		{"A", HighCard, HighCard},
+		{"J", HighCard, HighCard},
+		{"AAA", ThreeOfAKind, ThreeOfAKind},
+		{"JJ", OnePair, OnePair},
+		{"AAJ", OnePair, ThreeOfAKind},
+		{"AJ", HighCard, OnePair},
+	}
+
+	for _, test := range short {
+		t.Run(fmt.Sprintf("classify the short hand [%s] without panicking", test.hand), func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				assert.Equal(t, test.standard, StandardRule{}.Classify(test.hand), "Did not classify short hand correctly")
+				assert.Equal(t, test.joker, JokerRule{}.Classify(test.hand), "Did not classify short hand with jokers correctly")
+			}, "Did panic for a short hand")
+		})
+	}
+
+	t.Run("classify every possible hand", func(t *testing.T) {
+		expected := map[HandType]int{
+			HighCard:     13 * 12 * 11 * 10 * 9,
+			OnePair:      13 * 220 * 60,
+			TwoPair:      78 * 11 * 30,
+			ThreeOfAKind: 13 * 66 * 20,
+			FullHouse:    13 * 12 * 10,
+			FourOfAKind:  13 * 12 * 5,
+			FiveOfAKind:  13,
+		}
+
+		actual := make(map[HandType]int)
+		for _, hand := range allHands(cards, handSize) {
+			actual[StandardRule{}.Classify(hand)]++
+		}
+
+		assert.Equal(t, expected, actual, "Did not classify every possible hand correctly")
+	})
+
+	t.Run("classify jokers as the best substitution", func(t *testing.T) {
+		for _, hand := range allHands("234J", handSize) {
+			assert.Equal(t, bestSubstitution(hand), JokerRule{}.Classify(hand), "Did not classify "+hand+" with jokers correctly")
+		}
+	})
+
+}
+
+func TestHandOrderingShould(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		rule     RankingRule
+		expected []string
+	}{
+		{"rank hands", StandardRule{}, []string{"32T3K", "KTJJT", "KK677", "T55J5", "QQQJA"}},
+		{"rank hands with jokers", JokerRule{}, []string{"32T3K", "KK677", "T55J5", "QQQJA", "KTJJT"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hands := []Hand{{"32T3K", 765}, {"T55J5", 684}, {"KK677", 28}, {"KTJJT", 220}, {"QQQJA", 483}}
+
+			var actual []string
+			for _, hand := range Rank(hands, test.rule) {
+				actual = append(actual, hand.Cards)
+			}
+
+			assert.Equal(t, test.expected, actual, "Did not rank hands correctly")
+		})
+	}
+
+	comparisons := []struct {
+		weaker   string
+		stronger string
+		rule     RankingRule
+	}{
+		{"2AAAA", "33332", StandardRule{}},
+		{"TTTT2", "JJJJ2", StandardRule{}},
+		{"JTTT2", "TTTT2", JokerRule{}},
+		{"JKKK2", "QQQQ2", JokerRule{}},
+		{"JJJJJ", "22222", JokerRule{}},
+		{"KK677", "KTJJT", JokerRule{}},
+	}
+
+	for _, test := range comparisons {
+		t.Run(fmt.Sprintf("order %s below %s", test.weaker, test.stronger), func(t *testing.T) {
+			assert.Equal(t, -1, Compare(test.weaker, test.stronger, test.rule), "Did not order hands correctly")
+			assert.Equal(t, 1, Compare(test.stronger, test.weaker, test.rule), "Did not order hands correctly")
+			assert.Equal(t, 0, Compare(test.weaker, test.weaker, test.rule), "Did not order equal hands correctly")
+		})
+	}
+
+}
+
+func BenchmarkWinningsCalculation(b *testing.B) {
+
+	hands := make([]Hand, 0, 1000)
+	for i, cards := range allHands("2J9TA", handSize)[:1000] {
+		hands = append(hands, Hand{cards, i})
+	}
+
+	b.Run("winnings", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Winnings(hands, StandardRule{})
+		}
+	})
+
+	b.Run("winnings with jokers", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Winnings(hands, JokerRule{})
+		}
+	})
+
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"adventOfCode/common/fileops"
+	"adventOfCode/common/validation"
+	"adventOfCode/day7/camelcards"
+	"fmt"
+	"log"
+	"os"
+)
+
+var osExit = os.Exit
+
+func main() {
+	log.SetFlags(0)
+
+	path, err := validation.ExtractSingleArgIgnoringOthers(os.Args, 2)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	winnings, jokerWinnings, err := camelcards.CalculateWinnings(path, &fileops.FileReader{})
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(2)
+		return
+	}
+
+	fmt.Printf("The total winnings are %d\n", winnings)
+	fmt.Printf("The total winnings with jokers are %d\n", jokerWinnings)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"adventOfCode/common/fileops"
+	"adventOfCode/common/validation"
+	"adventOfCode/day8/network"
+	"fmt"
+	"log"
+	"os"
+)
+
+var osExit = os.Exit
+
+func main() {
+	log.SetFlags(0)
+
+	path, err := validation.ExtractSingleArgIgnoringOthers(os.Args, 2)
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(1)
+		return
+	}
+
+	steps, ghostSteps, err := network.CalculateSteps(
+		path,
+		&fileops.FileReader{},
+		network.WithWarningHandler(func(warning string) {
+			log.Printf("Warning: %s\n", warning)
+		}),
+	)
+
+	if steps >= 0 {
+		fmt.Printf("The number of steps to reach ZZZ is %d\n", steps)
+	}
+
+	if ghostSteps != nil {
+		fmt.Printf("The number of steps until all ghosts reach end nodes is %s\n", ghostSteps)
+	}
+
+	if err != nil {
+		log.Printf("Error: %s\n", err)
+		osExit(2)
+		return
+	}
+}
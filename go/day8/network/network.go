@@ -0,0 +1,391 @@
+package network
+
+import (
+	"adventOfCode/common/fileops"
+	"adventOfCode/common/mathx"
+	"bufio"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	startNode = "AAA"
+	endNode   = "ZZZ"
+)
+
+const maxExitCombinations = 1 << 16
+
+var nodePattern = regexp.MustCompile(`^(\w+)\s*=\s*\((\w+),\s*(\w+)\)$`)
+
+type Network struct {
+	instructions string
+	names        []string
+	index        map[string]int
+	next         [][2]int
+}
+
+type Cycle struct {
+	Start  string
+	Offset int
+	Length int
+	Exits  []int
+}
+
+func (c Cycle) transientExits() []int {
+	var exits []int
+	for _, exit := range c.Exits {
+		if exit < c.Offset {
+			exits = append(exits, exit)
+		}
+	}
+
+	return exits
+}
+
+func (c Cycle) cycleExits() []int {
+	var exits []int
+	for _, exit := range c.Exits {
+		if exit >= c.Offset {
+			exits = append(exits, exit)
+		}
+	}
+
+	return exits
+}
+
+func (c Cycle) isExit(step int) bool {
+	if step >= c.Offset {
+		step = c.Offset + (step-c.Offset)%c.Length
+	}
+
+	_, found := slices.BinarySearch(c.Exits, step)
+
+	return found
+}
+
+type Option func(*settings)
+
+type settings struct {
+	warn func(warning string)
+}
+
+func WithWarningHandler(handler func(warning string)) Option {
+	return func(s *settings) {
+		s.warn = handler
+	}
+}
+
+func CalculateSteps(path string, reader fileops.ReadableFile, opts ...Option) (steps int, ghostSteps *big.Int, errorMsg error) {
+	config := settings{
+		warn: func(string) {},
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	network, err := extractNetwork(path, reader)
+	if err != nil {
+		return -1, nil, err
+	}
+
+	steps = -1
+	var walkErr error
+	if _, found := network.index[startNode]; found {
+		steps, walkErr = network.Walk(startNode, endNode)
+	} else {
+		config.warn(fmt.Sprintf("no node [%s], skipping the walk to [%s]", startNode, endNode))
+	}
+
+	ghostSteps, ghostErr := network.GhostWalk(config.warn)
+
+	return steps, ghostSteps, errors.Join(walkErr, ghostErr)
+}
+
+func (n *Network) Walk(from string, to string) (int, error) {
+	current, ok := n.index[from]
+	if !ok {
+		return -1, fmt.Errorf("unknown node [%s]", from)
+	}
+
+	target, ok := n.index[to]
+	if !ok {
+		return -1, fmt.Errorf("unknown node [%s]", to)
+	}
+
+	for steps := 0; steps <= len(n.names)*len(n.instructions); steps++ {
+		if current == target {
+			return steps, nil
+		}
+
+		current = n.step(current, steps)
+	}
+
+	return -1, fmt.Errorf("node [%s] is unreachable from [%s]", to, from)
+}
+
+func (n *Network) FindCycle(start string) (Cycle, error) {
+	current, ok := n.index[start]
+	if !ok {
+		return Cycle{}, fmt.Errorf("unknown node [%s]", start)
+	}
+
+	visited := make([]int, len(n.names)*len(n.instructions))
+	for i := range visited {
+		visited[i] = -1
+	}
+
+	cycle := Cycle{Start: start}
+
+	for steps := 0; ; steps++ {
+		state := current*len(n.instructions) + steps%len(n.instructions)
+		if visited[state] >= 0 {
+			cycle.Offset = visited[state]
+			cycle.Length = steps - visited[state]
+			return cycle, nil
+		}
+		visited[state] = steps
+
+		if isGhostEnd(n.names[current]) {
+			cycle.Exits = append(cycle.Exits, steps)
+		}
+
+		current = n.step(current, steps)
+	}
+}
+
+func (n *Network) GhostWalk(warn func(warning string)) (*big.Int, error) {
+	var cycles []Cycle
+	for _, name := range n.names {
+		if isGhostStart(name) {
+			cycle, err := n.FindCycle(name)
+			if err != nil {
+				return nil, err
+			}
+
+			cycles = append(cycles, cycle)
+		}
+	}
+
+	if len(cycles) == 0 {
+		return nil, fmt.Errorf("no ghost start nodes found")
+	}
+
+	var periods []*big.Int
+	for _, cycle := range cycles {
+		period, warnings := checkAssumptions(cycle)
+		for _, warning := range warnings {
+			warn(warning)
+		}
+
+		if period > 0 {
+			periods = append(periods, big.NewInt(int64(period)))
+		}
+	}
+
+	settled := 0
+	for _, cycle := range cycles {
+		settled = max(settled, cycle.Offset)
+	}
+
+	if len(periods) == len(cycles) {
+		return firstAtOrAfter(mathx.Congruence{Residue: big.NewInt(0), Modulus: mathx.LCM(periods...)}, max(settled, 1)), nil
+	}
+
+	for steps := range settled {
+		if allAtExits(cycles, steps) {
+			return big.NewInt(int64(steps)), nil
+		}
+	}
+
+	return combineCycles(cycles, settled)
+}
+
+func (n *Network) step(current int, steps int) int {
+	if n.instructions[steps%len(n.instructions)] == 'L' {
+		return n.next[current][0]
+	}
+
+	return n.next[current][1]
+}
+
+func checkAssumptions(cycle Cycle) (int, []string) {
+	var warnings []string
+
+	if len(cycle.transientExits()) > 0 {
+		warnings = append(warnings, fmt.Sprintf("ghost starting at [%s] reaches an end node before entering its cycle", cycle.Start))
+	}
+
+	exits := cycle.cycleExits()
+	if len(exits) == 0 {
+		return 0, append(warnings, fmt.Sprintf("ghost starting at [%s] never reaches an end node in its cycle", cycle.Start))
+	}
+
+	period := cycle.Length
+	for _, exit := range exits {
+		period = int(mathx.GCD(big.NewInt(int64(period)), big.NewInt(int64(exit))).Int64())
+	}
+
+	if len(exits) != cycle.Length/period {
+		warnings = append(warnings, fmt.Sprintf("ghost starting at [%s] reaches end nodes at steps %v of a cycle of length %d, not at every multiple of a single period", cycle.Start, exits, cycle.Length))
+	}
+
+	if len(warnings) > 0 {
+		return 0, warnings
+	}
+
+	return period, nil
+}
+
+func allAtExits(cycles []Cycle, steps int) bool {
+	for _, cycle := range cycles {
+		if !cycle.isExit(steps) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func combineCycles(cycles []Cycle, settled int) (*big.Int, error) {
+	combinations := 1
+	for _, cycle := range cycles {
+		exits := len(cycle.cycleExits())
+		if exits == 0 {
+			return nil, fmt.Errorf("ghost starting at [%s] never reaches an end node in its cycle", cycle.Start)
+		}
+
+		combinations *= exits
+		if combinations > maxExitCombinations {
+			return nil, fmt.Errorf("too many end node combinations, more than %d", maxExitCombinations)
+		}
+	}
+
+	var best *big.Int
+	congruences := make([]mathx.Congruence, len(cycles))
+
+	var search func(i int)
+	search = func(i int) {
+		if i == len(cycles) {
+			solution, err := mathx.CRT(congruences...)
+			if err != nil {
+				return
+			}
+
+			steps := firstAtOrAfter(solution, settled)
+			if best == nil || steps.Cmp(best) < 0 {
+				best = steps
+			}
+			return
+		}
+
+		for _, exit := range cycles[i].cycleExits() {
+			congruences[i] = mathx.NewCongruence(int64(exit), int64(cycles[i].Length))
+			search(i + 1)
+		}
+	}
+	search(0)
+
+	if best == nil {
+		return nil, fmt.Errorf("ghosts never reach end nodes at the same time")
+	}
+
+	return best, nil
+}
+
+func firstAtOrAfter(solution mathx.Congruence, settled int) *big.Int {
+	steps := new(big.Int).Set(solution.Residue)
+
+	shortfall := new(big.Int).Sub(big.NewInt(int64(settled)), steps)
+	if shortfall.Sign() > 0 {
+		periods := new(big.Int).Add(shortfall, new(big.Int).Sub(solution.Modulus, big.NewInt(1)))
+		periods.Quo(periods, solution.Modulus)
+		steps.Add(steps, periods.Mul(periods, solution.Modulus))
+	}
+
+	return steps
+}
+
+func isGhostStart(name string) bool {
+	return strings.HasSuffix(name, "A")
+}
+
+func isGhostEnd(name string) bool {
+	return strings.HasSuffix(name, "Z")
+}
+
+func extractNetwork(path string, reader fileops.ReadableFile) (*Network, error) {
+	file, err := fileops.OpenFile(path, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = fileops.CloseFile(file)
+	}()
+
+	network := &Network{index: make(map[string]int)}
+	var links [][2]string
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			continue
+		case network.instructions == "":
+			if err := validateInstructions(line); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			network.instructions = line
+		default:
+			match := nodePattern.FindStringSubmatch(line)
+			if match == nil {
+				return nil, fmt.Errorf("line %d: malformed node [%s]", lineNumber, line)
+			}
+
+			if _, found := network.index[match[1]]; found {
+				return nil, fmt.Errorf("line %d: duplicate node [%s]", lineNumber, match[1])
+			}
+
+			network.index[match[1]] = len(network.names)
+			network.names = append(network.names, match[1])
+			links = append(links, [2]string{match[2], match[3]})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if network.instructions == "" {
+		return nil, fmt.Errorf("no instructions found")
+	}
+
+	network.next = make([][2]int, len(links))
+	for i, link := range links {
+		for side, name := range link {
+			target, found := network.index[name]
+			if !found {
+				return nil, fmt.Errorf("node [%s] leads to unknown node [%s]", network.names[i], name)
+			}
+			network.next[i][side] = target
+		}
+	}
+
+	return network, nil
+}
+
+func validateInstructions(line string) error {
+	for _, instruction := range line {
+		if instruction != 'L' && instruction != 'R' {
+			return fmt.Errorf("unknown instruction [%c] in [%s]", instruction, line)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,325 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+type MockFileReader struct {
+	mock.Mock
+}
+
+func (mockReader *MockFileReader) Open(path string) (io.ReadCloser, error) {
+	args := mockReader.Called(path)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+const repeatingNetwork = "LLR\n\nAAA = (BBB, BBB)\nBBB = (AAA, ZZZ)\nZZZ = (ZZZ, ZZZ)"
+
+const ghostNetwork = "LR\n\n" +
+	"AAA = (11B, XXX)\n11B = (XXX, ZZZ)\nZZZ = (11B, XXX)\n" +
+	"22A = (22B, XXX)\n22B = (22C, 22C)\n22C = (22Z, 22Z)\n22Z = (22B, 22B)\n" +
+	"XXX = (XXX, XXX)"
+
+func networkFrom(t *testing.T, lines string) *Network {
+	const fileName = "test_input.txt"
+
+	mockReader := new(MockFileReader)
+	mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+	network, err := extractNetwork(fileName, mockReader)
+	assert.Nil(t, err, "Did not extract network")
+
+	return network
+}
+
+func randomNetwork(random *rand.Rand) string {
+	var builder strings.Builder
+
+	for range 1 + random.Intn(4) {
+		builder.WriteByte("LR"[random.Intn(2)])
+	}
+	builder.WriteString("\n\n")
+
+	names := []string{"AAA", "ZZZ"}
+	for i := range 2 + random.Intn(8) {
+		names = append(names, fmt.Sprintf("%c%d%c", 'B'+i, i, "AZX"[random.Intn(3)]))
+	}
+
+	for _, name := range names {
+		_, _ = fmt.Fprintf(&builder, "%s = (%s, %s)\n", name, names[random.Intn(len(names))], names[random.Intn(len(names))])
+	}
+
+	return builder.String()
+}
+
+func bruteForceGhostWalk(n *Network, limit int) (int, bool) {
+	var current []int
+	for i, name := range n.names {
+		if isGhostStart(name) {
+			current = append(current, i)
+		}
+	}
+
+	for steps := 0; steps <= limit; steps++ {
+		finished := true
+		for _, node := range current {
+			finished = finished && isGhostEnd(n.names[node])
+		}
+
+		if finished {
+			return steps, true
+		}
+
+		for i, node := range current {
+			current[i] = n.step(node, steps)
+		}
+	}
+
+	return -1, false
+}
+
+func TestStepsCalculationShould(t *testing.T) {
+
+	t.Run("calculate steps", func(t *testing.T) {
+		const fileName = "test_input.txt"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(ghostNetwork)), nil)
+
+		actual, _, err := CalculateSteps(fileName, mockReader)
+
+		assert.Nil(t, err, "Did not calculate steps")
+		assert.Equal(t, 2, actual, "Did not calculate steps correctly")
+	})
+
+	t.Run("calculate ghost steps", func(t *testing.T) {
+		const fileName = "test_input.txt"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(ghostNetwork)), nil)
+
+		var warnings []string
+		_, actual, err := CalculateSteps(fileName, mockReader, WithWarningHandler(func(warning string) {
+			warnings = append(warnings, warning)
+		}))
+
+		assert.Nil(t, err, "Did not calculate ghost steps")
+		assert.Equal(t, "6", actual.String(), "Did not calculate ghost steps correctly")
+		assert.Empty(t, warnings, "Did warn for a well behaved network")
+	})
+
+	t.Run("calculate steps when repeating instructions", func(t *testing.T) {
+		const fileName = "test_input.txt"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(repeatingNetwork)), nil)
+
+		actual, _, err := CalculateSteps(fileName, mockReader)
+
+		assert.Nil(t, err, "Did not calculate steps")
+		assert.Equal(t, 6, actual, "Did not calculate steps correctly")
+	})
+
+	t.Run("calculate ghost steps without a start node", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "LR\n\n11A = (11B, XXX)\n11B = (XXX, 11Z)\n11Z = (11B, XXX)\n" +
+			"22A = (22B, XXX)\n22B = (22C, 22C)\n22C = (22Z, 22Z)\n22Z = (22B, 22B)\nXXX = (XXX, XXX)"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		var warnings []string
+		steps, ghostSteps, err := CalculateSteps(fileName, mockReader, WithWarningHandler(func(warning string) {
+			warnings = append(warnings, warning)
+		}))
+
+		assert.Nil(t, err, "Did not calculate ghost steps")
+		assert.Equal(t, -1, steps, "Did not skip the walk without a start node")
+		assert.Equal(t, "6", ghostSteps.String(), "Did not calculate ghost steps correctly")
+		assert.Equal(t, []string{"no node [AAA], skipping the walk to [ZZZ]"}, warnings, "Did not warn about skipping the walk")
+	})
+
+	t.Run("keep the steps when ghosts never finish", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "L\n\nAAA = (ZZZ, ZZZ)\nZZZ = (AAA, AAA)\n11A = (11A, 11A)\n"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		steps, ghostSteps, err := CalculateSteps(fileName, mockReader)
+
+		assert.EqualError(t, err, "ghost starting at [11A] never reaches an end node in its cycle", "Did not fail for ghosts that never finish")
+		assert.Equal(t, 1, steps, "Did not keep the steps")
+		assert.Nil(t, ghostSteps, "Did report ghost steps")
+	})
+
+	t.Run("keep the ghost steps when ZZZ is unreachable", func(t *testing.T) {
+		const fileName = "test_input.txt"
+		const lines = "L\n\nAAA = (11Z, 11Z)\n11Z = (AAA, AAA)\nZZZ = (ZZZ, ZZZ)\n"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(lines)), nil)
+
+		steps, ghostSteps, err := CalculateSteps(fileName, mockReader)
+
+		assert.EqualError(t, err, "node [ZZZ] is unreachable from [AAA]", "Did not fail for an unreachable end node")
+		assert.Equal(t, -1, steps, "Did report steps")
+		assert.Equal(t, "1", ghostSteps.String(), "Did not keep the ghost steps")
+	})
+
+	failures := []struct {
+		name     string
+		lines    string
+		expected string
+	}{
+		{"missing instructions", "", "no instructions found"},
+		{"unknown instructions", "LRX\n\nAAA = (AAA, AAA)\n", "line 1: unknown instruction [X] in [LRX]"},
+		{"malformed nodes", "LR\n\nAAA = (AAA AAA)\n", "line 3: malformed node [AAA = (AAA AAA)]"},
+		{"duplicate nodes", "LR\n\nAAA = (ZZZ, ZZZ)\nAAA = (ZZZ, ZZZ)\n", "line 4: duplicate node [AAA]"},
+		{"unknown links", "LR\n\nAAA = (ZZZ, QQQ)\nZZZ = (ZZZ, ZZZ)\n", "node [AAA] leads to unknown node [QQQ]"},
+		{"missing end nodes", "LR\n\nAAA = (AAA, AAA)\n",
+			"unknown node [ZZZ]\nghost starting at [AAA] never reaches an end node in its cycle"},
+		{"unreachable end nodes", "LR\n\nAAA = (AAA, AAA)\nZZZ = (ZZZ, ZZZ)\n",
+			"node [ZZZ] is unreachable from [AAA]\nghost starting at [AAA] never reaches an end node in its cycle"},
+		{"networks without ghosts", "LR\n\nBBB = (ZZZ, ZZZ)\nZZZ = (ZZZ, ZZZ)\n", "no ghost start nodes found"},
+		{"ghosts that never finish", "L\n\nAAA = (ZZZ, ZZZ)\nZZZ = (AAA, AAA)\n11A = (11A, 11A)\n", "ghost starting at [11A] never reaches an end node in its cycle"},
+		{"ghosts that never finish together", "L\n\nAAA = (ZZZ, ZZZ)\nZZZ = (AAA, AAA)\n11A = (11B, 11B)\n11B = (11Z, 11Z)\n11Z = (11C, 11C)\n11C = (11A, 11A)\n",
+			"ghosts never reach end nodes at the same time"},
+	}
+
+	for _, test := range failures {
+		t.Run("fail for "+test.name, func(t *testing.T) {
+			const fileName = "test_input.txt"
+
+			mockReader := new(MockFileReader)
+			mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader(test.lines)), nil)
+
+			_, _, err := CalculateSteps(fileName, mockReader)
+
+			assert.EqualError(t, err, test.expected, "Did not fail for "+test.name)
+		})
+	}
+
+	t.Run("fail when unable to read file", func(t *testing.T) {
+		const fileName = "test_input.txt"
+
+		mockReader := new(MockFileReader)
+		mockReader.On("Open", fileName).Return(io.NopCloser(strings.NewReader("")), errors.New("file open error"))
+
+		_, _, err := CalculateSteps(fileName, mockReader)
+
+		assert.EqualError(t, err, "file open error", "Did not fail when unable to read file")
+	})
+
+}
+
+func TestCycleDetectionShould(t *testing.T) {
+
+	t.Run("find the cycle of a ghost", func(t *testing.T) {
+		network := networkFrom(t, ghostNetwork)
+
+		actual, err := network.FindCycle("22A")
+
+		assert.Nil(t, err, "Did not find cycle")
+		assert.Equal(t, Cycle{Start: "22A", Offset: 1, Length: 6, Exits: []int{3, 6}}, actual, "Did not find cycle correctly")
+	})
+
+	tests := []struct {
+		name     string
+		lines    string
+		expected []string
+	}{
+		{"end nodes before the cycle", "L\n\n11A = (11Z, 11Z)\n11Z = (11B, 11B)\n11B = (11B, 11B)\n",
+			[]string{
+				"ghost starting at [11A] reaches an end node before entering its cycle",
+				"ghost starting at [11A] never reaches an end node in its cycle",
+			}},
+		{"several end nodes in a cycle", "L\n\nAAA = (11Z, 11Z)\n11Z = (ZZZ, ZZZ)\nZZZ = (11B, 11B)\n11B = (11C, 11C)\n11C = (11Z, 11Z)\n",
+			[]string{"ghost starting at [AAA] reaches end nodes at steps [1 2] of a cycle of length 4, not at every multiple of a single period"}},
+		{"end nodes out of step with the cycle", "L\n\nAAA = (11B, 11B)\n11B = (ZZZ, ZZZ)\nZZZ = (11C, 11C)\n11C = (11B, 11B)\n",
+			[]string{"ghost starting at [AAA] reaches end nodes at steps [2] of a cycle of length 3, not at every multiple of a single period"}},
+	}
+
+	for _, test := range tests {
+		t.Run("warn for "+test.name, func(t *testing.T) {
+			network := networkFrom(t, test.lines)
+
+			var actual []string
+			_, _ = network.GhostWalk(func(warning string) {
+				actual = append(actual, warning)
+			})
+
+			assert.Equal(t, test.expected, actual, "Did not warn about cycle assumptions")
+		})
+	}
+
+	t.Run("solve well behaved ghosts with the least common multiple of their periods", func(t *testing.T) {
+		network := networkFrom(t, "L\n\n11A = (11B, 11B)\n11B = (11C, 11C)\n11C = (11Z, 11Z)\n11Z = (11B, 11B)\n"+
+			"22A = (22B, 22B)\n22B = (22Z, 22Z)\n22Z = (22B, 22B)\n")
+
+		var warnings []string
+		actual, err := network.GhostWalk(func(warning string) {
+			warnings = append(warnings, warning)
+		})
+
+		assert.Nil(t, err, "Did not calculate ghost steps")
+		assert.Empty(t, warnings, "Did warn for well behaved ghosts")
+		assert.Equal(t, "6", actual.String(), "Did not calculate ghost steps correctly")
+	})
+
+	t.Run("solve ghosts whose cycles are not well behaved", func(t *testing.T) {
+		network := networkFrom(t, "L\n\nAAA = (11B, 11B)\n11B = (ZZZ, ZZZ)\nZZZ = (11C, 11C)\n11C = (11B, 11B)\n"+
+			"22A = (22Z, 22Z)\n22Z = (22B, 22B)\n22B = (22Z, 22Z)\n")
+
+		actual, err := network.GhostWalk(func(string) {})
+
+		assert.Nil(t, err, "Did not calculate ghost steps")
+		assert.Equal(t, "5", actual.String(), "Did not calculate ghost steps correctly")
+	})
+
+	t.Run("agree with brute force for random networks", func(t *testing.T) {
+		random := rand.New(rand.NewSource(8))
+
+		for range 2000 {
+			lines := randomNetwork(random)
+			network := networkFrom(t, lines)
+
+			expected, ok := bruteForceGhostWalk(network, 5000)
+			actual, err := network.GhostWalk(func(string) {})
+
+			if ok {
+				assert.Nil(t, err, "Did not calculate ghost steps for\n"+lines)
+				if err == nil {
+					assert.Equal(t, fmt.Sprint(expected), actual.String(), "Did not agree with brute force for\n"+lines)
+				}
+			} else {
+				assert.NotNil(t, err, "Did not fail for\n"+lines)
+			}
+		}
+	})
+
+}
+
+func BenchmarkStepsCalculation(b *testing.B) {
+
+	random := rand.New(rand.NewSource(8))
+	lines := randomNetwork(random)
+
+	mockReader := new(MockFileReader)
+	mockReader.On("Open", "test_input.txt").Return(io.NopCloser(strings.NewReader(lines)), nil)
+	network, _ := extractNetwork("test_input.txt", mockReader)
+
+	b.Run("ghost walk", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = network.GhostWalk(func(string) {})
+		}
+	})
+
+}
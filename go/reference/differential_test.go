@@ -0,0 +1,218 @@
+package reference
+
+import (
+	"adventOfCode/common/gen"
+	"adventOfCode/day1/coordinates"
+	"adventOfCode/day2/gameids"
+	"adventOfCode/day3/schematic"
+	"adventOfCode/day4/scratchcards"
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+const seedsPerDay = 1000
+
+type stringReader string
+
+func (input stringReader) Open(string) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(string(input))), nil
+}
+
+type solver func(input string) (any, error)
+
+func optimised(day int) solver {
+	switch day {
+	case 1:
+		return func(input string) (any, error) {
+			return coordinates.CalculateTotal("input.txt", stringReader(input))
+		}
+	case 2:
+		return func(input string) (any, error) {
+			idTotal, powerTotal, err := gameids.CalculateTotals("input.txt", stringReader(input))
+			return [2]int{idTotal, powerTotal}, err
+		}
+	case 3:
+		return func(input string) (any, error) {
+			partTotal, gearTotal, err := schematic.CalculateTotals("input.txt", stringReader(input))
+			return [2]int{partTotal, gearTotal}, err
+		}
+	}
+
+	return func(input string) (any, error) {
+		score, count, err := scratchcards.CalculateTotals("input.txt", stringReader(input))
+		return [2]int{score, count}, err
+	}
+}
+
+func brute(day int) solver {
+	switch day {
+	case 1:
+		return func(input string) (any, error) {
+			return Calibrations(input), nil
+		}
+	case 2:
+		return func(input string) (any, error) {
+			idTotal, powerTotal := Games(input, gameids.DefaultBag())
+			return [2]int{idTotal, powerTotal}, nil
+		}
+	case 3:
+		return func(input string) (any, error) {
+			partTotal, gearTotal := Schematic(input)
+			return [2]int{partTotal, gearTotal}, nil
+		}
+	}
+
+	return func(input string) (any, error) {
+		score, count := Scratchcards(input)
+		return [2]int{score, count}, nil
+	}
+}
+
+func generatorOptions(day int, seed int64) (int, []gen.Option) {
+	switch day {
+	case 1:
+		return 1 + int(seed%20), []gen.Option{gen.WithWordRate(float64(seed%5) / 4)}
+	case 2:
+		return 1 + int(seed%10), []gen.Option{gen.WithRounds(1 + int(seed%4)), gen.WithMaxCubes(5 + int(seed%20))}
+	case 3:
+		return 3 + int(seed%12), []gen.Option{gen.WithDensity(0.1+float64(seed%4)/10, 0.05+float64(seed%3)/10)}
+	}
+
+	return 1 + int(seed%12), []gen.Option{gen.WithPoolSizes(1+int(seed%5), 1+int(seed%10)), gen.WithWinRate(float64(seed%4) / 6)}
+}
+
+type failureKind int
+
+const (
+	succeeded failureKind = iota
+	returnedError
+	panicked
+)
+
+type outcome struct {
+	value   any
+	failure failureKind
+	detail  string
+}
+
+func (o outcome) String() string {
+	switch o.failure {
+	case returnedError:
+		return "error: " + o.detail
+	case panicked:
+		return "panic: " + o.detail
+	}
+
+	return fmt.Sprint(o.value)
+}
+
+func run(solve solver, input string) (result outcome) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result = outcome{failure: panicked, detail: fmt.Sprint(recovered)}
+		}
+	}()
+
+	value, err := solve(input)
+	if err != nil {
+		return outcome{failure: returnedError, detail: err.Error()}
+	}
+
+	return outcome{value: value}
+}
+
+func disagree(expected outcome, actual outcome) bool {
+	if expected.failure != succeeded || actual.failure != succeeded {
+		return expected.failure != actual.failure
+	}
+
+	return fmt.Sprint(expected.value) != fmt.Sprint(actual.value)
+}
+
+func mismatch(optimised solver, brute solver) func(lines []string) bool {
+	return func(lines []string) bool {
+		input := strings.Join(lines, "\n")
+
+		return disagree(run(brute, input), run(optimised, input))
+	}
+}
+
+func TestMismatchShould(t *testing.T) {
+
+	succeed := func(value int) solver {
+		return func(string) (any, error) {
+			return value, nil
+		}
+	}
+	fail := func(string) (any, error) {
+		return nil, errors.New("solver error")
+	}
+	crash := func(string) (any, error) {
+		panic("solver panic")
+	}
+
+	tests := []struct {
+		name      string
+		optimised solver
+		brute     solver
+		expected  bool
+	}{
+		{"agreeing results", succeed(1), succeed(1), false},
+		{"different results", succeed(1), succeed(2), true},
+		{"an optimised error", fail, succeed(1), true},
+		{"an optimised panic", crash, succeed(1), true},
+		{"a reference error", succeed(1), fail, true},
+		{"a reference panic", succeed(1), crash, true},
+		{"errors on both sides", fail, fail, false},
+		{"panics on both sides", crash, crash, false},
+		{"an error against a panic", fail, crash, true},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("detect a mismatch for %s: %t", test.name, test.expected), func(t *testing.T) {
+			actual := mismatch(test.optimised, test.brute)([]string{"input"})
+
+			assert.Equal(t, test.expected, actual, "Did not detect mismatch correctly")
+		})
+	}
+
+}
+
+func TestDifferentialShould(t *testing.T) {
+
+	for _, day := range gen.Days() {
+		t.Run(fmt.Sprintf("agree with the reference solver for day %d", day), func(t *testing.T) {
+			failing := mismatch(optimised(day), brute(day))
+
+			for seed := range int64(seedsPerDay) {
+				size, opts := generatorOptions(day, seed)
+
+				var out bytes.Buffer
+				err := gen.Generate(&out, day, seed, size, opts...)
+				assert.Nil(t, err, "Did not generate input")
+
+				lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+				if failing(lines) {
+					counterexample := strings.Join(Shrink(lines, failing), "\n")
+					input := strings.Join(lines, "\n")
+					expected := run(brute(day), counterexample)
+					actual := run(optimised(day), counterexample)
+
+					assert.Failf(
+						t,
+						"Did not agree with the reference solver",
+						"seed %d\nminimal counterexample:\n%s\nreference: %v\noptimised: %v\noriginal input:\n%s",
+						seed, counterexample, expected, actual, input,
+					)
+					return
+				}
+			}
+		})
+	}
+
+}
@@ -0,0 +1,212 @@
+package reference
+
+import (
+	"strconv"
+	"strings"
+)
+
+var digitWords = []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+
+func Calibrations(input string) int {
+	total := 0
+
+	for _, line := range lines(input) {
+		var digits []int
+
+		for i := range line {
+			if line[i] >= '1' && line[i] <= '9' {
+				digits = append(digits, int(line[i]-'0'))
+			}
+
+			for idx, word := range digitWords {
+				if strings.HasPrefix(line[i:], word) {
+					digits = append(digits, idx+1)
+				}
+			}
+		}
+
+		if len(digits) > 0 {
+			total += digits[0]*10 + digits[len(digits)-1]
+		}
+	}
+
+	return total
+}
+
+func Games(input string, bag map[string]int) (idTotal int, powerTotal int) {
+	for _, line := range lines(input) {
+		header, rounds, _ := strings.Cut(line, ": ")
+		id, _ := strconv.Atoi(strings.TrimPrefix(header, "Game "))
+
+		possible := true
+		minimum := make(map[string]int)
+
+		for _, round := range strings.Split(rounds, "; ") {
+			for _, draw := range strings.Split(round, ", ") {
+				countText, color, _ := strings.Cut(draw, " ")
+				count, _ := strconv.Atoi(countText)
+
+				if count > bag[color] {
+					possible = false
+				}
+				if count > minimum[color] {
+					minimum[color] = count
+				}
+			}
+		}
+
+		if possible {
+			idTotal += id
+		}
+
+		power := 1
+		for _, count := range minimum {
+			power *= count
+		}
+		powerTotal += power
+	}
+
+	return idTotal, powerTotal
+}
+
+type number struct {
+	value int
+	row   int
+	first int
+	last  int
+}
+
+func Schematic(input string) (partTotal int, gearTotal int) {
+	rows := lines(input)
+
+	var numbers []number
+	for row, line := range rows {
+		for col := 0; col < len(line); col++ {
+			if !isDigit(line[col]) {
+				continue
+			}
+
+			first := col
+			for col < len(line) && isDigit(line[col]) {
+				col++
+			}
+
+			value, _ := strconv.Atoi(line[first:col])
+			numbers = append(numbers, number{value, row, first, col - 1})
+		}
+	}
+
+	for _, num := range numbers {
+		touchesSymbol := false
+
+		for row, line := range rows {
+			for col := range line {
+				if isSymbol(line[col]) && touches(num, row, col) {
+					touchesSymbol = true
+				}
+			}
+		}
+
+		if touchesSymbol {
+			partTotal += num.value
+		}
+	}
+
+	for row, line := range rows {
+		for col := range line {
+			if line[col] != '*' {
+				continue
+			}
+
+			var parts []int
+			for _, num := range numbers {
+				if touches(num, row, col) {
+					parts = append(parts, num.value)
+				}
+			}
+
+			if len(parts) == 2 {
+				gearTotal += parts[0] * parts[1]
+			}
+		}
+	}
+
+	return partTotal, gearTotal
+}
+
+func Scratchcards(input string) (score int, count int) {
+	var matches []int
+
+	for _, line := range lines(input) {
+		_, numbers, _ := strings.Cut(line, ":")
+		winningText, drawnText, _ := strings.Cut(numbers, "|")
+		winning := strings.Fields(winningText)
+
+		cardMatches := 0
+		for _, drawn := range strings.Fields(drawnText) {
+			for _, win := range winning {
+				if drawn == win {
+					cardMatches++
+				}
+			}
+		}
+
+		matches = append(matches, cardMatches)
+
+		cardScore := 0
+		for range cardMatches {
+			if cardScore == 0 {
+				cardScore = 1
+			} else {
+				cardScore *= 2
+			}
+		}
+		score += cardScore
+	}
+
+	for card := range matches {
+		count += copyCard(card, matches)
+	}
+
+	return score, count
+}
+
+func copyCard(card int, matches []int) int {
+	copies := 1
+
+	for next := card + 1; next <= card+matches[card] && next < len(matches); next++ {
+		copies += copyCard(next, matches)
+	}
+
+	return copies
+}
+
+func lines(input string) []string {
+	trimmed := strings.TrimSuffix(input, "\n")
+	if trimmed == "" {
+		return nil
+	}
+
+	return strings.Split(trimmed, "\n")
+}
+
+func touches(num number, row int, col int) bool {
+	for numCol := num.first; numCol <= num.last; numCol++ {
+		rowDistance := num.row - row
+		colDistance := numCol - col
+
+		if rowDistance >= -1 && rowDistance <= 1 && colDistance >= -1 && colDistance <= 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isDigit(cell byte) bool {
+	return cell >= '0' && cell <= '9'
+}
+
+func isSymbol(cell byte) bool {
+	return cell != '.' && !isDigit(cell)
+}
@@ -0,0 +1,89 @@
+package reference
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestReferenceShould(t *testing.T) {
+
+	t.Run("solve calibrations", func(t *testing.T) {
+		const input = "two1nine\neightwothree\nabcone2threexyz\nxtwone3four\n4nineeightseven2\nzoneight234\n7pqrstsixteen\nnothing"
+
+		assert.Equal(t, 281, Calibrations(input), "Did not solve calibrations")
+	})
+
+	t.Run("solve games", func(t *testing.T) {
+		const input = "Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green\n" +
+			"Game 2: 1 blue, 2 green; 3 green, 4 blue, 1 red; 1 green, 1 blue\n" +
+			"Game 3: 8 green, 6 blue, 20 red; 5 blue, 4 red, 13 green; 5 green, 1 red\n" +
+			"Game 4: 1 green, 3 red, 6 blue; 3 green, 6 red; 3 green, 15 blue, 14 red\n" +
+			"Game 5: 6 red, 1 blue, 3 green; 2 blue, 1 red, 2 green\n"
+
+		idTotal, powerTotal := Games(input, map[string]int{"red": 12, "green": 13, "blue": 14})
+
+		assert.Equal(t, 8, idTotal, "Did not solve game ids")
+		assert.Equal(t, 2286, powerTotal, "Did not solve game powers")
+	})
+
+	t.Run("solve schematics", func(t *testing.T) {
+		const input = "467..114..\n...*......\n..35..633.\n......#...\n617*......\n.....+.58.\n..592.....\n......755.\n...$.*....\n.664.598.."
+
+		partTotal, gearTotal := Schematic(input)
+
+		assert.Equal(t, 4361, partTotal, "Did not solve part numbers")
+		assert.Equal(t, 467835, gearTotal, "Did not solve gear ratios")
+	})
+
+	t.Run("solve scratchcards", func(t *testing.T) {
+		const input = "Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53\n" +
+			"Card 2: 13 32 20 16 61 | 61 30 68 82 17 32 24 19\n" +
+			"Card 3:  1 21 53 59 44 | 69 82 63 72 16 21 14  1\n" +
+			"Card 4: 41 92 73 84 69 | 59 84 76 51 58  5 54 83\n" +
+			"Card 5: 87 83 26 28 32 | 88 30 70 12 93 22 82 36\n" +
+			"Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11\n"
+
+		score, count := Scratchcards(input)
+
+		assert.Equal(t, 13, score, "Did not solve scratchcard scores")
+		assert.Equal(t, 30, count, "Did not solve scratchcard count")
+	})
+
+}
+
+func TestShrinkingShould(t *testing.T) {
+
+	t.Run("shrink to a minimal counterexample", func(t *testing.T) {
+		failing := func(lines []string) bool {
+			return strings.Contains(strings.Join(lines, "\n"), "7*")
+		}
+
+		actual := Shrink([]string{"467..114..", "...*......", "..35..633.", "617*......", ".....+.58."}, failing)
+
+		assert.Equal(t, []string{"7*"}, actual, "Did not shrink to a minimal counterexample")
+	})
+
+	t.Run("keep lines needed to reproduce a failure", func(t *testing.T) {
+		failing := func(lines []string) bool {
+			return len(lines) >= 2 && strings.Contains(lines[0], "a") && strings.Contains(lines[len(lines)-1], "b")
+		}
+
+		actual := Shrink([]string{"xa", "yy", "zz", "bw"}, failing)
+
+		assert.Equal(t, []string{"a", "b"}, actual, "Did not keep required lines")
+	})
+
+}
+
+func BenchmarkReference(b *testing.B) {
+
+	b.Run("schematic reference", func(b *testing.B) {
+		const input = "467..114..\n...*......\n..35..633.\n......#...\n617*......\n.....+.58.\n..592.....\n......755.\n...$.*....\n.664.598.."
+
+		for i := 0; i < b.N; i++ {
+			Schematic(input)
+		}
+	})
+
+}
@@ -0,0 +1,50 @@
+package reference
+
+func Shrink(lines []string, failing func(lines []string) bool) []string {
+	lines = shrinkLines(lines, failing)
+
+	for i := range lines {
+		lines = shrinkLine(lines, i, failing)
+	}
+
+	return lines
+}
+
+func shrinkLines(lines []string, failing func(lines []string) bool) []string {
+	for size := len(lines) / 2; size > 0; size /= 2 {
+		for start := 0; start+size <= len(lines); {
+			candidate := append(append([]string{}, lines[:start]...), lines[start+size:]...)
+
+			if failing(candidate) {
+				lines = candidate
+			} else {
+				start += size
+			}
+		}
+	}
+
+	return lines
+}
+
+func shrinkLine(lines []string, idx int, failing func(lines []string) bool) []string {
+	for col := 0; col < len(lines[idx]); col++ {
+		line := lines[idx]
+
+		for _, replacement := range []string{"", "."} {
+			if line[col:col+1] == replacement {
+				continue
+			}
+
+			candidate := append([]string{}, lines...)
+			candidate[idx] = line[:col] + replacement + line[col+1:]
+
+			if failing(candidate) {
+				lines = candidate
+				col = -1
+				break
+			}
+		}
+	}
+
+	return lines
+}